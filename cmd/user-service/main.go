@@ -8,6 +8,7 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/lovechung/go-kit/bootstrap"
 	"user-service/internal/conf"
+	"user-service/internal/server"
 )
 
 // go build -ldflags "-X main.Service.Version=x.y.z"
@@ -26,7 +27,7 @@ func init() {
 	Flags.Init()
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, rr registry.Registrar) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, ps *server.PurgeServer, bs *server.BackfillServer, rs *server.PasswordResetServer, fs *server.IdFilterServer, rr registry.Registrar) *kratos.App {
 	// 自定义服务端点
 	//endpointStr := Flags.Endpoint
 	//if endpointStr == "" {
@@ -40,7 +41,7 @@ func newApp(logger log.Logger, gs *grpc.Server, rr registry.Registrar) *kratos.A
 		kratos.Metadata(Service.Metadata),
 		//kratos.Endpoint(endpoint),
 		kratos.Logger(logger),
-		kratos.Server(gs),
+		kratos.Server(gs, ps, bs, rs, fs),
 		kratos.Registrar(rr),
 	)
 }
@@ -92,7 +93,7 @@ func main() {
 
 	logger := loadLogger(bc)
 
-	app, cleanup, err := wireApp(bc.Server, bc.Data, rc, logger)
+	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Auth, rc, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -19,20 +19,66 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, registry *conf.Registry, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, confData *conf.Data, auth *conf.Auth, registry *conf.Registry, logger log.Logger) (*kratos.App, func(), error) {
+	keySet, err := biz.NewTokenKeySet(auth)
+	if err != nil {
+		return nil, nil, err
+	}
 	client := data.NewDB(confData, logger)
 	rueidisClient := data.NewRedis(confData, logger)
 	dataData, cleanup, err := data.NewData(client, rueidisClient, logger)
 	if err != nil {
 		return nil, nil, err
 	}
-	userRepo := data.NewUserRepo(dataData, logger)
+	roleRepo := data.NewRoleRepo(dataData, logger)
+	authorizer := biz.NewAuthorizer(roleRepo, logger)
+	userRepo := data.NewUserRepo(dataData, confData, logger)
 	transaction := data.NewTransaction(dataData)
-	userUseCase := biz.NewUserUseCase(userRepo, transaction, logger)
-	userService := service.NewUserService(userUseCase, logger)
-	grpcServer := server.NewGRPCServer(confServer, userService, logger)
+	passwordHasher := biz.NewPasswordHasher(auth)
+	breachedPasswordRepo, err := data.NewBreachedPasswordRepo(auth, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	passwordPolicy := biz.NewPasswordPolicy(auth, breachedPasswordRepo)
+	codec, err := biz.NewCursorCodec(confServer, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	userUseCase := biz.NewUserUseCase(userRepo, transaction, passwordHasher, passwordPolicy, codec, logger)
+	totpRepo := data.NewTotpRepo(dataData, logger)
+	totpUseCase, err := biz.NewTotpUseCase(auth, totpRepo, transaction, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	oneTimeTokenRepo := data.NewOneTimeTokenRepo(dataData, logger)
+	mailer, err := data.NewMailer(confData, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	emailUseCase := biz.NewEmailUseCase(auth, userRepo, transaction, oneTimeTokenRepo, mailer, keySet, logger)
+	refreshTokenRepo := data.NewRefreshTokenRepo(dataData, logger)
+	loginLockoutRepo := data.NewLoginLockoutRepo(dataData, logger)
+	lockout := biz.NewLockout(auth, loginLockoutRepo, logger)
+	authUseCase := biz.NewAuthUseCase(auth, userUseCase, totpUseCase, emailUseCase, refreshTokenRepo, lockout, keySet, logger)
+	roleUseCase := biz.NewRoleUseCase(roleRepo, transaction, logger)
+	passwordResetUseCase := biz.NewPasswordResetUseCase(auth, userUseCase, oneTimeTokenRepo, refreshTokenRepo, mailer, logger)
+	ipResolver, err := service.NewIPResolver(confServer)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	userService := service.NewUserService(userUseCase, authUseCase, roleUseCase, totpUseCase, emailUseCase, passwordResetUseCase, ipResolver, logger)
+	grpcServer := server.NewGRPCServer(confServer, auth, keySet, authorizer, userService, logger)
+	purgeServer := server.NewPurgeServer(confData, userUseCase, logger)
+	backfillServer := server.NewBackfillServer(userUseCase, logger)
+	passwordResetServer := server.NewPasswordResetServer(passwordResetUseCase)
+	idFilterServer := server.NewIdFilterServer(confData, userUseCase, logger)
 	registrar := data.NewRegistrar(registry)
-	app := newApp(logger, grpcServer, registrar)
+	app := newApp(logger, grpcServer, purgeServer, backfillServer, passwordResetServer, idFilterServer, registrar)
 	return app, func() {
 		cleanup()
 	}, nil
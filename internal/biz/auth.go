@@ -0,0 +1,338 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	jwtV4 "github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"user-service/internal/conf"
+	ex "user-service/internal/pkg/errors"
+	"user-service/internal/pkg/jwtkey"
+)
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	// TokenTypeMfa 密码校验通过、等待两步验证的凭证
+	TokenTypeMfa = "mfa"
+
+	defaultAccessTTL    = 15 * time.Minute
+	defaultRefreshTTL   = 7 * 24 * time.Hour
+	defaultChallengeTTL = 5 * time.Minute
+)
+
+type Claims struct {
+	jwtV4.RegisteredClaims
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"typ"`
+	FamilyId  string `json:"fid,omitempty"`
+}
+
+type RefreshTokenState int
+
+const (
+	RefreshTokenInvalid RefreshTokenState = iota
+	RefreshTokenValid
+	RefreshTokenReused
+)
+
+// RefreshToken 已签发的refresh token，同一次登录后轮换出的token属于同一个family
+type RefreshToken struct {
+	Id        string
+	FamilyId  string
+	UserId    int64
+	ExpiresAt time.Time
+}
+
+type RefreshTokenRepo interface {
+	Save(ctx context.Context, t *RefreshToken) error
+	// Use 将token标记为已使用，并返回标记前的状态
+	Use(ctx context.Context, t *RefreshToken) (RefreshTokenState, error)
+	RevokeFamily(ctx context.Context, userId int64, familyId string) error
+	RevokeAll(ctx context.Context, userId int64) error
+}
+
+// Token 登录结果；需要两步验证时只有MfaToken及其有效期
+type Token struct {
+	AccessToken      string
+	RefreshToken     string
+	ExpiresIn        time.Duration
+	RefreshExpiresIn time.Duration
+	MfaToken         string
+}
+
+type AuthUseCase struct {
+	uc           *UserUseCase
+	totp         *TotpUseCase
+	email        *EmailUseCase
+	tokenRepo    RefreshTokenRepo
+	lockout      *Lockout
+	keys         *jwtkey.KeySet
+	issuer       string
+	audience     []string
+	accessTTL    time.Duration
+	refreshTTL   time.Duration
+	challengeTTL time.Duration
+	log          *log.Helper
+}
+
+func NewTokenKeySet(c *conf.Auth) (*jwtkey.KeySet, error) {
+	return jwtkey.New(c.GetJwt())
+}
+
+func NewAuthUseCase(c *conf.Auth, uc *UserUseCase, totp *TotpUseCase, email *EmailUseCase, tokenRepo RefreshTokenRepo, lockout *Lockout, keys *jwtkey.KeySet, logger log.Logger) *AuthUseCase {
+	ac := &AuthUseCase{
+		uc:           uc,
+		totp:         totp,
+		email:        email,
+		tokenRepo:    tokenRepo,
+		lockout:      lockout,
+		keys:         keys,
+		issuer:       c.GetJwt().GetIssuer(),
+		audience:     c.GetJwt().GetAudience(),
+		accessTTL:    defaultAccessTTL,
+		refreshTTL:   defaultRefreshTTL,
+		challengeTTL: defaultChallengeTTL,
+		log:          log.NewHelper(logger),
+	}
+	if ttl := c.GetJwt().GetAccessTtl(); ttl != nil {
+		ac.accessTTL = ttl.AsDuration()
+	}
+	if ttl := c.GetJwt().GetRefreshTtl(); ttl != nil {
+		ac.refreshTTL = ttl.AsDuration()
+	}
+	if ttl := c.GetMfa().GetChallengeTtl(); ttl != nil {
+		ac.challengeTTL = ttl.AsDuration()
+	}
+	return ac
+}
+
+// Login 校验用户名密码并签发access及refresh token，ip为调用方的来源地址
+func (ac *AuthUseCase) Login(ctx context.Context, username, password, ip string) (*Token, error) {
+	// 锁定期内不校验密码，避免被继续暴力尝试
+	if err := ac.lockout.Check(ctx, username, ip); err != nil {
+		return nil, err
+	}
+	u, err := ac.uc.VerifyPassword(ctx, username, password)
+	if err != nil {
+		if errors.Is(err, ex.InvalidCredentials) {
+			if lerr := ac.lockout.Fail(ctx, username, ip); lerr != nil {
+				return nil, lerr
+			}
+		}
+		if errors.Is(err, ex.UserIsPending) {
+			ac.resendVerification(ctx, username)
+		}
+		return nil, err
+	}
+
+	// 启用了两步验证时，先签发短期的挑战凭证，凭验证码换取token；
+	// 此时不清除失败记录，待两步验证通过后再清除
+	enabled, err := ac.totp.Enabled(ctx, u.Id)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return ac.issueChallenge(u.Id, *u.Username)
+	}
+	// 每次登录开启一个新的token family
+	token, err := ac.issueToken(ctx, u.Id, *u.Username, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+	ac.lockout.Reset(ctx, username)
+	return token, nil
+}
+
+// resendVerification 待验证的账号密码正确时重新发送验证邮件，发送失败不影响登录结果
+func (ac *AuthUseCase) resendVerification(ctx context.Context, username string) {
+	u, err := ac.uc.r.GetByUsername(ctx, username)
+	if err == nil {
+		err = ac.email.SendVerification(ctx, u.Id)
+	}
+	if err != nil {
+		ac.log.WithContext(ctx).Warnf("重新发送用户%s的验证邮件失败: %v", username, err)
+	}
+}
+
+// VerifyMfa 校验两步验证码（或恢复码），通过后签发token；验证码错误单独计数，不会被密码登录成功清除
+func (ac *AuthUseCase) VerifyMfa(ctx context.Context, mfaToken, code, ip string) (*Token, error) {
+	claims := &Claims{}
+	token, err := jwtV4.ParseWithClaims(mfaToken, claims, ac.keys.Keyfunc)
+	if err != nil || !token.Valid || claims.TokenType != TokenTypeMfa {
+		return nil, ex.MfaTokenInvalid
+	}
+	if ac.issuer != "" && !claims.VerifyIssuer(ac.issuer, true) {
+		return nil, ex.MfaTokenInvalid
+	}
+	userId, _ := strconv.ParseInt(claims.Subject, 10, 64)
+
+	if err = ac.lockout.CheckMfa(ctx, claims.Username, ip); err != nil {
+		return nil, err
+	}
+	ok, err := ac.totp.Verify(ctx, userId, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if lerr := ac.lockout.FailMfa(ctx, claims.Username, ip); lerr != nil {
+			return nil, lerr
+		}
+		return nil, ex.InvalidMfaCode
+	}
+
+	if err = ac.uc.CheckLoginStatus(ctx, userId); err != nil {
+		return nil, err
+	}
+	t, err := ac.issueToken(ctx, userId, claims.Username, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+	ac.lockout.Reset(ctx, claims.Username)
+	return t, nil
+}
+
+// RefreshToken 使用refresh token换取新的token，旧的refresh token随即失效；
+// 已使用过的refresh token被重放时，视为泄露并吊销其所在的整个family
+func (ac *AuthUseCase) RefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
+	claims, err := ac.parseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	userId, _ := strconv.ParseInt(claims.Subject, 10, 64)
+	// 登录后被冻结、禁用的账号不再续期
+	if err = ac.uc.CheckLoginStatus(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	state, err := ac.tokenRepo.Use(ctx, &RefreshToken{
+		Id:       claims.ID,
+		FamilyId: claims.FamilyId,
+		UserId:   userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch state {
+	case RefreshTokenValid:
+		return ac.issueToken(ctx, userId, claims.Username, claims.FamilyId)
+	case RefreshTokenReused:
+		ac.log.WithContext(ctx).Warnf("用户id=%d的refresh token被重复使用，已吊销family=%s", userId, claims.FamilyId)
+		return nil, ex.RefreshTokenReused
+	default:
+		return nil, ex.RefreshTokenInvalid
+	}
+}
+
+// Logout 吊销refresh token所在的family
+func (ac *AuthUseCase) Logout(ctx context.Context, refreshToken string) error {
+	claims, err := ac.parseRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+	userId, _ := strconv.ParseInt(claims.Subject, 10, 64)
+	return ac.tokenRepo.RevokeFamily(ctx, userId, claims.FamilyId)
+}
+
+// LogoutAll 吊销当前用户的全部refresh token
+func (ac *AuthUseCase) LogoutAll(ctx context.Context) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ex.Unauthenticated
+	}
+	return ac.tokenRepo.RevokeAll(ctx, p.UserId)
+}
+
+// ClearLockout 解除用户及来源IP的登录锁定，userId为0时只解除IP
+func (ac *AuthUseCase) ClearLockout(ctx context.Context, userId int64, ip string) error {
+	var username string
+	if userId != 0 {
+		u, err := ac.uc.r.GetUsername(ctx, userId)
+		if err != nil {
+			return err
+		}
+		username = *u.Username
+	}
+	if err := ac.lockout.Clear(ctx, username, ip); err != nil {
+		return err
+	}
+	ac.log.WithContext(ctx).Infof("已解除用户id=%d、来源%s的登录锁定", userId, ip)
+	return nil
+}
+
+func (ac *AuthUseCase) parseRefreshToken(refreshToken string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwtV4.ParseWithClaims(refreshToken, claims, ac.keys.Keyfunc)
+	if err != nil {
+		var ve *jwtV4.ValidationError
+		if errors.As(err, &ve) && ve.Errors&jwtV4.ValidationErrorExpired != 0 {
+			return nil, ex.RefreshTokenExpired
+		}
+		return nil, ex.RefreshTokenInvalid
+	}
+	if !token.Valid || claims.TokenType != TokenTypeRefresh || claims.FamilyId == "" {
+		return nil, ex.RefreshTokenInvalid
+	}
+	if ac.issuer != "" && !claims.VerifyIssuer(ac.issuer, true) {
+		return nil, ex.RefreshTokenInvalid
+	}
+	return claims, nil
+}
+
+func (ac *AuthUseCase) issueToken(ctx context.Context, userId int64, username, familyId string) (*Token, error) {
+	now := time.Now()
+	access, err := ac.keys.Sign(ac.newClaims(userId, username, TokenTypeAccess, now, ac.accessTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshClaims := ac.newClaims(userId, username, TokenTypeRefresh, now, ac.refreshTTL)
+	refreshClaims.FamilyId = familyId
+	refresh, err := ac.keys.Sign(refreshClaims)
+	if err != nil {
+		return nil, err
+	}
+	if err = ac.tokenRepo.Save(ctx, &RefreshToken{
+		Id:        refreshClaims.ID,
+		FamilyId:  familyId,
+		UserId:    userId,
+		ExpiresAt: refreshClaims.ExpiresAt.Time,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &Token{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		ExpiresIn:        ac.accessTTL,
+		RefreshExpiresIn: ac.refreshTTL,
+	}, nil
+}
+
+func (ac *AuthUseCase) issueChallenge(userId int64, username string) (*Token, error) {
+	mfa, err := ac.keys.Sign(ac.newClaims(userId, username, TokenTypeMfa, time.Now(), ac.challengeTTL))
+	if err != nil {
+		return nil, err
+	}
+	return &Token{MfaToken: mfa, ExpiresIn: ac.challengeTTL}, nil
+}
+
+func (ac *AuthUseCase) newClaims(userId int64, username, tokenType string, now time.Time, ttl time.Duration) *Claims {
+	return &Claims{
+		RegisteredClaims: jwtV4.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    ac.issuer,
+			Subject:   strconv.FormatInt(userId, 10),
+			Audience:  ac.audience,
+			IssuedAt:  jwtV4.NewNumericDate(now),
+			NotBefore: jwtV4.NewNumericDate(now),
+			ExpiresAt: jwtV4.NewNumericDate(now.Add(ttl)),
+		},
+		Username:  username,
+		TokenType: tokenType,
+	}
+}
@@ -6,7 +6,20 @@ import (
 )
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewUserUseCase)
+var ProviderSet = wire.NewSet(
+	NewUserUseCase,
+	NewPasswordHasher,
+	NewPasswordPolicy,
+	NewAuthUseCase,
+	NewLockout,
+	NewTotpUseCase,
+	NewEmailUseCase,
+	NewPasswordResetUseCase,
+	NewTokenKeySet,
+	NewCursorCodec,
+	NewAuthorizer,
+	NewRoleUseCase,
+)
 
 type Transaction interface {
 	ExecTx(context.Context, func(ctx context.Context) error) error
@@ -0,0 +1,172 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	jwtV4 "github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"user-service/internal/conf"
+	ex "user-service/internal/pkg/errors"
+	"user-service/internal/pkg/jwtkey"
+)
+
+const (
+	TokenTypeEmailVerify = "email_verify"
+
+	defaultEmailVerifyTTL = 24 * time.Hour
+)
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer 邮件发送，由data层提供SMTP及仅打印日志的实现
+type Mailer interface {
+	Send(ctx context.Context, m *Mail) error
+}
+
+// OneTimeTokenRepo 一次性token，同一用户同一用途只保留最新签发的一个
+type OneTimeTokenRepo interface {
+	Save(ctx context.Context, purpose string, userId int64, tokenHash string, ttl time.Duration) error
+	// Consume token有效时删除并返回true
+	Consume(ctx context.Context, purpose string, userId int64, tokenHash string) (bool, error)
+	// Cooldown 同一用户同一用途在interval内只允许一次，返回本次是否允许
+	Cooldown(ctx context.Context, purpose string, userId int64, interval time.Duration) (bool, error)
+}
+
+// EmailClaims 邮箱验证token，签名后发送到待验证的邮箱
+type EmailClaims struct {
+	jwtV4.RegisteredClaims
+	TokenType string `json:"typ"`
+	Email     string `json:"email"`
+}
+
+type EmailUseCase struct {
+	r         UserRepo
+	tx        Transaction
+	tokenRepo OneTimeTokenRepo
+	mailer    Mailer
+	keys      *jwtkey.KeySet
+	issuer    string
+	verifyURL string
+	verifyTTL time.Duration
+	log       *log.Helper
+}
+
+func NewEmailUseCase(c *conf.Auth, r UserRepo, tx Transaction, tokenRepo OneTimeTokenRepo, mailer Mailer, keys *jwtkey.KeySet, logger log.Logger) *EmailUseCase {
+	uc := &EmailUseCase{
+		r:         r,
+		tx:        tx,
+		tokenRepo: tokenRepo,
+		mailer:    mailer,
+		keys:      keys,
+		issuer:    c.GetJwt().GetIssuer(),
+		verifyURL: c.GetEmail().GetVerifyUrl(),
+		verifyTTL: defaultEmailVerifyTTL,
+		log:       log.NewHelper(logger),
+	}
+	if ttl := c.GetEmail().GetVerifyTtl(); ttl != nil {
+		uc.verifyTTL = ttl.AsDuration()
+	}
+	return uc
+}
+
+// RequestVerification 向当前用户的邮箱发送验证邮件，之前发送的验证链接随即失效
+func (uc *EmailUseCase) RequestVerification(ctx context.Context) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ex.Unauthenticated
+	}
+	return uc.SendVerification(ctx, p.UserId)
+}
+
+// SendVerification 向指定用户的邮箱发送验证邮件，注册时及待验证账号登录时调用
+func (uc *EmailUseCase) SendVerification(ctx context.Context, userId int64) error {
+	u, err := uc.r.GetById(ctx, userId, []string{UserFieldId, UserFieldUsername, UserFieldEmail, UserFieldEmailVerified})
+	if err != nil {
+		return err
+	}
+	if u.Email == nil {
+		return ex.EmailNotSet
+	}
+	if u.EmailVerifiedAt != nil {
+		return ex.EmailAlreadyVerified
+	}
+
+	now := time.Now()
+	claims := &EmailClaims{
+		RegisteredClaims: jwtV4.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    uc.issuer,
+			Subject:   strconv.FormatInt(u.Id, 10),
+			IssuedAt:  jwtV4.NewNumericDate(now),
+			ExpiresAt: jwtV4.NewNumericDate(now.Add(uc.verifyTTL)),
+		},
+		TokenType: TokenTypeEmailVerify,
+		Email:     *u.Email,
+	}
+	token, err := uc.keys.Sign(claims)
+	if err != nil {
+		return err
+	}
+	if err = uc.tokenRepo.Save(ctx, TokenTypeEmailVerify, u.Id, hashToken(claims.ID), uc.verifyTTL); err != nil {
+		return err
+	}
+	return uc.mailer.Send(ctx, &Mail{
+		To:      *u.Email,
+		Subject: "请验证您的邮箱",
+		Body: fmt.Sprintf("您好，%s：\n\n请在%s前点击以下链接完成邮箱验证：\n%s\n\n如非本人操作，请忽略本邮件。",
+			*u.Username, claims.ExpiresAt.Time.Format("2006-01-02 15:04"), fmt.Sprintf(uc.verifyURL, token)),
+	})
+}
+
+// Verify 校验邮件中的token，token只能使用一次，且签发后邮箱被修改则失效
+func (uc *EmailUseCase) Verify(ctx context.Context, token string) error {
+	claims := &EmailClaims{}
+	t, err := jwtV4.ParseWithClaims(token, claims, uc.keys.Keyfunc)
+	if err != nil || !t.Valid || claims.TokenType != TokenTypeEmailVerify {
+		return ex.VerificationTokenInvalid
+	}
+	if uc.issuer != "" && !claims.VerifyIssuer(uc.issuer, true) {
+		return ex.VerificationTokenInvalid
+	}
+	userId, _ := strconv.ParseInt(claims.Subject, 10, 64)
+
+	ok, err := uc.tokenRepo.Consume(ctx, TokenTypeEmailVerify, userId, hashToken(claims.ID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ex.VerificationTokenInvalid
+	}
+	// 标记已验证，待验证的账号同时激活
+	return uc.tx.ExecTx(ctx, func(ctx context.Context) error {
+		if err := uc.r.MarkEmailVerified(ctx, userId, claims.Email); err != nil {
+			return err
+		}
+		status, err := uc.r.GetStatus(ctx, userId)
+		if err != nil {
+			return err
+		}
+		if status == UserStatusPending {
+			if err = uc.r.UpdateStatus(ctx, userId, UserStatusPending, UserStatusActive, "邮箱已验证", userId); err != nil {
+				return err
+			}
+		}
+		uc.log.WithContext(ctx).Infof("用户id=%d已验证邮箱", userId)
+		return nil
+	})
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,173 @@
+package biz
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/conf"
+	ex "user-service/internal/pkg/errors"
+)
+
+const (
+	defaultFailureWindow       = 15 * time.Minute
+	defaultLockoutBaseDuration = time.Minute
+	defaultLockoutMaxDuration  = 24 * time.Hour
+)
+
+// LockoutPolicy 某一维度（用户或来源IP）的锁定规则
+type LockoutPolicy struct {
+	MaxFailures  int
+	Window       time.Duration
+	BaseDuration time.Duration
+	MaxDuration  time.Duration
+}
+
+func (p LockoutPolicy) Enabled() bool {
+	return p.MaxFailures > 0
+}
+
+type LoginLockoutRepo interface {
+	// LockedUntil 返回多个对象中最晚的锁定截止时间，均未锁定时返回零值
+	LockedUntil(ctx context.Context, subjects ...string) (time.Time, error)
+	// Fail 记录一次失败，达到阈值时锁定并返回锁定截止时间，否则返回零值
+	Fail(ctx context.Context, subject string, p LockoutPolicy) (time.Time, error)
+	// Clear 清除失败次数及锁定状态
+	Clear(ctx context.Context, subjects ...string) error
+}
+
+// Lockout 登录失败次数过多时按用户名及来源IP锁定，锁定时长指数递增
+type Lockout struct {
+	r    LoginLockoutRepo
+	user LockoutPolicy
+	ip   LockoutPolicy
+	log  *log.Helper
+}
+
+func NewLockout(c *conf.Auth, r LoginLockoutRepo, logger log.Logger) *Lockout {
+	lc := c.GetLockout()
+	base := LockoutPolicy{
+		Window:       defaultFailureWindow,
+		BaseDuration: defaultLockoutBaseDuration,
+		MaxDuration:  defaultLockoutMaxDuration,
+	}
+	if d := lc.GetFailureWindow(); d != nil {
+		base.Window = d.AsDuration()
+	}
+	if d := lc.GetBaseDuration(); d != nil {
+		base.BaseDuration = d.AsDuration()
+	}
+	if d := lc.GetMaxDuration(); d != nil {
+		base.MaxDuration = d.AsDuration()
+	}
+	l := &Lockout{r: r, user: base, ip: base, log: log.NewHelper(logger)}
+	l.user.MaxFailures = int(lc.GetUserMaxFailures())
+	l.ip.MaxFailures = int(lc.GetIpMaxFailures())
+	return l
+}
+
+func userSubject(username string) string {
+	return "user:" + NormalizeUsername(username)
+}
+
+// mfaSubject 两步验证码的失败次数单独统计，密码登录成功不会清除
+func mfaSubject(username string) string {
+	return "mfa:" + NormalizeUsername(username)
+}
+
+func ipSubject(ip string) string {
+	return "ip:" + ip
+}
+
+// Check 用户名或来源IP处于锁定期时返回ex.AccountLocked；
+// redis不可用时放行，避免影响正常登录
+func (l *Lockout) Check(ctx context.Context, username, ip string) error {
+	until, err := l.r.LockedUntil(ctx, l.subjects(username, ip)...)
+	if err != nil {
+		l.log.WithContext(ctx).Warnf("查询登录锁定状态失败: %v", err)
+		return nil
+	}
+	return lockedErr(until)
+}
+
+// CheckMfa 在Check的基础上同时检查两步验证码的锁定状态
+func (l *Lockout) CheckMfa(ctx context.Context, username, ip string) error {
+	until, err := l.r.LockedUntil(ctx, append(l.subjects(username, ip), mfaSubject(username))...)
+	if err != nil {
+		l.log.WithContext(ctx).Warnf("查询登录锁定状态失败: %v", err)
+		return nil
+	}
+	return lockedErr(until)
+}
+
+// Fail 记录一次登录失败，本次失败触发锁定时返回ex.AccountLocked
+func (l *Lockout) Fail(ctx context.Context, username, ip string) error {
+	return l.fail(ctx, userSubject(username), username, ip)
+}
+
+// FailMfa 记录一次两步验证码错误，与密码错误分开计数
+func (l *Lockout) FailMfa(ctx context.Context, username, ip string) error {
+	return l.fail(ctx, mfaSubject(username), username, ip)
+}
+
+func (l *Lockout) fail(ctx context.Context, subject, username, ip string) error {
+	var until time.Time
+	if l.user.Enabled() {
+		t, err := l.r.Fail(ctx, subject, l.user)
+		if err != nil {
+			l.log.WithContext(ctx).Warnf("记录登录失败次数失败: %v", err)
+		}
+		until = t
+	}
+	if l.ip.Enabled() && ip != "" {
+		t, err := l.r.Fail(ctx, ipSubject(ip), l.ip)
+		if err != nil {
+			l.log.WithContext(ctx).Warnf("记录登录失败次数失败: %v", err)
+		}
+		if t.After(until) {
+			until = t
+		}
+	}
+	if !until.IsZero() {
+		l.log.WithContext(ctx).Warnf("用户名%s（来源%s）登录失败次数过多，锁定至%s", username, ip, until.Format(time.RFC3339))
+	}
+	return lockedErr(until)
+}
+
+// Reset 登录成功（含两步验证）后清除该用户名的失败记录，来源IP的记录保留
+func (l *Lockout) Reset(ctx context.Context, username string) {
+	if err := l.r.Clear(ctx, userSubject(username), mfaSubject(username)); err != nil {
+		l.log.WithContext(ctx).Warnf("清除登录失败次数失败: %v", err)
+	}
+}
+
+// Clear 管理员手动解除用户名（含两步验证）及来源IP的锁定
+func (l *Lockout) Clear(ctx context.Context, username, ip string) error {
+	subjects := l.subjects(username, ip)
+	if username != "" {
+		subjects = append(subjects, mfaSubject(username))
+	}
+	return l.r.Clear(ctx, subjects...)
+}
+
+func (l *Lockout) subjects(username, ip string) []string {
+	subjects := make([]string, 0, 2)
+	if username != "" {
+		subjects = append(subjects, userSubject(username))
+	}
+	if ip != "" {
+		subjects = append(subjects, ipSubject(ip))
+	}
+	return subjects
+}
+
+func lockedErr(until time.Time) error {
+	if until.IsZero() || !until.After(time.Now()) {
+		return nil
+	}
+	return ex.AccountLocked.WithMetadata(map[string]string{
+		"locked_until": until.Format(time.RFC3339),
+		"retry_after":  strconv.Itoa(int(time.Until(until).Seconds()) + 1),
+	})
+}
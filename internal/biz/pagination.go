@@ -0,0 +1,94 @@
+package biz
+
+import (
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/conf"
+	"user-service/internal/pkg/cursor"
+	ex "user-service/internal/pkg/errors"
+)
+
+// UserCursor 游标分页的位置，列表按(created_at, id)倒序排列
+type UserCursor struct {
+	CreatedAt time.Time
+	Id        int64
+	// Backward 向前翻页，取排在该位置之前的数据
+	Backward bool
+}
+
+// userCursorToken 游标token的内容，同时记录查询条件，游标不能用于其他条件的查询
+type userCursorToken struct {
+	CreatedAt int64   `json:"t"`
+	Id        int64   `json:"i"`
+	Backward  bool    `json:"b,omitempty"`
+	Username  *string `json:"u,omitempty"`
+}
+
+func NewCursorCodec(c *conf.Server, logger log.Logger) (*cursor.Codec, error) {
+	if secret := c.GetPagination().GetCursorSecret(); secret != "" {
+		return cursor.New([]byte(secret)), nil
+	}
+	log.NewHelper(logger).Warn("未配置分页游标密钥，使用随机密钥，重启后已签发的游标失效")
+	return cursor.NewRandom()
+}
+
+func (uc *UserUseCase) decodeCursor(token string, username *string) (*UserCursor, error) {
+	t := &userCursorToken{}
+	if err := uc.cursors.Decode(token, t); err != nil {
+		return nil, ex.InvalidPageToken
+	}
+	if !equalStringPtr(t.Username, username) {
+		return nil, ex.InvalidPageToken
+	}
+	return &UserCursor{
+		CreatedAt: time.Unix(0, t.CreatedAt),
+		Id:        t.Id,
+		Backward:  t.Backward,
+	}, nil
+}
+
+// encodeCursor 以u所在的位置生成游标，u须包含created_at
+func (uc *UserUseCase) encodeCursor(u *User, backward bool, username *string) (string, error) {
+	t := &userCursorToken{Id: u.Id, Backward: backward, Username: username}
+	if u.CreatedAt != nil {
+		t.CreatedAt = u.CreatedAt.UnixNano()
+	}
+	return uc.cursors.Encode(t)
+}
+
+// setPageTokens 根据本页首尾的用户生成前后翻页的游标
+func (uc *UserUseCase) setPageTokens(q *UserListQuery, page *UserPage) error {
+	if len(page.List) == 0 {
+		return nil
+	}
+	hasNext, hasPrev := page.HasMore, q.Page > 1
+	if q.Cursor != nil {
+		// 向前翻页时，仓储层返回的HasMore表示前面是否还有数据
+		hasNext, hasPrev = page.HasMore, true
+		if q.Cursor.Backward {
+			hasNext, hasPrev = true, page.HasMore
+		}
+	}
+	page.HasMore = hasNext
+
+	var err error
+	if hasNext {
+		if page.NextPageToken, err = uc.encodeCursor(page.List[len(page.List)-1], false, q.Username); err != nil {
+			return err
+		}
+	}
+	if hasPrev {
+		if page.PrevPageToken, err = uc.encodeCursor(page.List[0], true, q.Username); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
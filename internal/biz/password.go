@@ -0,0 +1,208 @@
+package biz
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"user-service/internal/conf"
+)
+
+const (
+	PasswordAlgArgon2id = "argon2id"
+	PasswordAlgBcrypt   = "bcrypt"
+)
+
+var ErrInvalidPasswordHash = errors.New("invalid password hash")
+
+// PasswordHasher 密码哈希器，生成的哈希均为PHC格式（bcrypt为其兼容的$2a$/$2b$格式）
+type PasswordHasher interface {
+	// Hash 按当前参数生成密码哈希
+	Hash(password string) (string, error)
+	// Verify 校验密码，needsRehash为true时表示应按当前参数重新生成哈希
+	Verify(password, encoded string) (ok bool, needsRehash bool, err error)
+}
+
+// passwordAlgorithm 具体的哈希算法，能识别自己生成的哈希
+type passwordAlgorithm interface {
+	PasswordHasher
+	Match(encoded string) bool
+}
+
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params 参考RFC 9106的推荐参数
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+type argon2idHasher struct {
+	p Argon2Params
+}
+
+func NewArgon2idHasher(p Argon2Params) PasswordHasher {
+	return &argon2idHasher{p: p}
+}
+
+func (h *argon2idHasher) Match(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+func (h *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.p.Iterations, h.p.Memory, h.p.Parallelism, h.p.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.p.Memory, h.p.Iterations, h.p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2idHasher) Verify(password, encoded string) (bool, bool, error) {
+	// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != PasswordAlgArgon2id {
+		return false, false, ErrInvalidPasswordHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, ErrInvalidPasswordHash
+	}
+	var p Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	p.SaltLength, p.KeyLength = uint32(len(salt)), uint32(len(key))
+
+	actual := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return false, false, nil
+	}
+	return true, p != h.p, nil
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) PasswordHasher {
+	return &bcryptHasher{cost: cost}
+}
+
+func (h *bcryptHasher) Match(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	b, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	return string(b), err
+}
+
+func (h *bcryptHasher) Verify(password, encoded string) (bool, bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	return true, cost != h.cost, nil
+}
+
+// passwordHasher 用配置的算法生成哈希，校验时按哈希前缀分派到对应算法
+type passwordHasher struct {
+	current    passwordAlgorithm
+	algorithms []passwordAlgorithm
+}
+
+func NewPasswordHasher(c *conf.Auth) PasswordHasher {
+	ap := DefaultArgon2Params
+	cost := bcrypt.DefaultCost
+	alg := PasswordAlgArgon2id
+	if pc := c.GetPassword(); pc != nil {
+		if a := pc.Argon2; a != nil {
+			if a.Memory > 0 {
+				ap.Memory = a.Memory
+			}
+			if a.Iterations > 0 {
+				ap.Iterations = a.Iterations
+			}
+			if a.Parallelism > 0 {
+				ap.Parallelism = uint8(a.Parallelism)
+			}
+			if a.SaltLength > 0 {
+				ap.SaltLength = a.SaltLength
+			}
+			if a.KeyLength > 0 {
+				ap.KeyLength = a.KeyLength
+			}
+		}
+		if pc.BcryptCost > 0 {
+			cost = int(pc.BcryptCost)
+		}
+		if pc.Algorithm != "" {
+			alg = pc.Algorithm
+		}
+	}
+
+	argon2id := &argon2idHasher{p: ap}
+	bcryptH := &bcryptHasher{cost: cost}
+	h := &passwordHasher{algorithms: []passwordAlgorithm{argon2id, bcryptH}}
+	switch alg {
+	case PasswordAlgBcrypt:
+		h.current = bcryptH
+	default:
+		h.current = argon2id
+	}
+	return h
+}
+
+func (h *passwordHasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+func (h *passwordHasher) Verify(password, encoded string) (bool, bool, error) {
+	for _, alg := range h.algorithms {
+		if !alg.Match(encoded) {
+			continue
+		}
+		ok, needsRehash, err := alg.Verify(password, encoded)
+		// 算法变更后，旧算法生成的哈希也需要升级
+		return ok, ok && (needsRehash || alg != h.current), err
+	}
+	// 不是已知算法的哈希时按历史明文数据处理，校验通过后需要升级为哈希
+	ok := encoded != "" && subtle.ConstantTimeCompare([]byte(password), []byte(encoded)) == 1
+	return ok, ok, nil
+}
@@ -0,0 +1,109 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"user-service/internal/conf"
+	ex "user-service/internal/pkg/errors"
+	"user-service/internal/pkg/validate"
+)
+
+const defaultPasswordMinLength = 8
+
+// BreachedPasswordRepo 已泄露密码库
+type BreachedPasswordRepo interface {
+	Contains(ctx context.Context, password string) (bool, error)
+}
+
+// PasswordPolicy 设置或修改密码时的强度要求
+type PasswordPolicy struct {
+	minLength        int
+	minCharClasses   int
+	maxRepeated      int
+	disallowUsername bool
+	breached         BreachedPasswordRepo
+}
+
+func NewPasswordPolicy(c *conf.Auth, breached BreachedPasswordRepo) *PasswordPolicy {
+	pc := c.GetPassword().GetPolicy()
+	p := &PasswordPolicy{
+		minLength:        int(pc.GetMinLength()),
+		minCharClasses:   int(pc.GetMinCharClasses()),
+		maxRepeated:      int(pc.GetMaxRepeated()),
+		disallowUsername: pc.GetDisallowUsername(),
+		breached:         breached,
+	}
+	if p.minLength <= 0 {
+		p.minLength = defaultPasswordMinLength
+	}
+	return p
+}
+
+// DisallowUsername 是否需要用户名参与校验
+func (p *PasswordPolicy) DisallowUsername() bool {
+	return p.disallowUsername
+}
+
+// Check 校验密码强度，不符合时返回带有全部违规项的ex.WeakPassword
+func (p *PasswordPolicy) Check(ctx context.Context, password, username string) error {
+	v := &validate.Violations{}
+	const field = "password"
+
+	v.Check(utf8.RuneCountInString(password) >= p.minLength, field, "密码长度不能少于%d位", p.minLength)
+	if p.minCharClasses > 0 {
+		v.Check(charClasses(password) >= p.minCharClasses, field,
+			"密码须至少包含小写字母、大写字母、数字、符号中的%d种", p.minCharClasses)
+	}
+	if p.maxRepeated > 0 {
+		v.Check(maxRepeatedRun(password) <= p.maxRepeated, field, "同一字符不能连续出现超过%d次", p.maxRepeated)
+	}
+	if p.disallowUsername && utf8.RuneCountInString(username) >= 3 {
+		v.Check(!strings.Contains(strings.ToLower(password), strings.ToLower(username)), field, "密码不能包含用户名")
+	}
+	if v.Empty() && p.breached != nil {
+		// 其他规则都通过时才查询泄露库
+		breached, err := p.breached.Contains(ctx, password)
+		if err != nil {
+			return err
+		}
+		v.Check(!breached, field, "该密码已在公开的泄露数据中出现，请更换")
+	}
+	return v.ErrAs(ex.WeakPassword)
+}
+
+func charClasses(s string) int {
+	var lower, upper, digit, symbol int
+	for _, c := range s {
+		switch {
+		case unicode.IsLower(c):
+			lower = 1
+		case unicode.IsUpper(c):
+			upper = 1
+		case unicode.IsDigit(c):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+func maxRepeatedRun(s string) int {
+	var longest, run int
+	var prev rune = -1
+	for _, c := range s {
+		if c == prev {
+			run++
+		} else {
+			run = 1
+			prev = c
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
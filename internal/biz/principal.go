@@ -0,0 +1,21 @@
+package biz
+
+import "context"
+
+// Principal 当前请求的调用者，由鉴权中间件从access token中解析得到
+type Principal struct {
+	UserId   int64
+	Username string
+	TokenId  string
+}
+
+type principalKey struct{}
+
+func NewPrincipalContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
@@ -0,0 +1,196 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/conf"
+	ex "user-service/internal/pkg/errors"
+)
+
+const (
+	tokenPurposePasswordReset = "password_reset"
+
+	defaultPasswordResetTTL      = 30 * time.Minute
+	defaultPasswordResetCooldown = time.Minute
+	// 单个重置请求的处理超时时间
+	resetMailTimeout = 30 * time.Second
+	// 排队等待处理的重置请求上限，超出时丢弃
+	resetQueueSize = 256
+)
+
+// PasswordResetUseCase 通过邮件中的一次性token重置密码
+type PasswordResetUseCase struct {
+	uc        *UserUseCase
+	tokenRepo OneTimeTokenRepo
+	sessions  RefreshTokenRepo
+	mailer    Mailer
+	resetURL  string
+	resetTTL  time.Duration
+	cooldown  time.Duration
+	queue     chan string
+	log       *log.Helper
+}
+
+func NewPasswordResetUseCase(c *conf.Auth, uc *UserUseCase, tokenRepo OneTimeTokenRepo, sessions RefreshTokenRepo, mailer Mailer, logger log.Logger) *PasswordResetUseCase {
+	rc := &PasswordResetUseCase{
+		uc:        uc,
+		tokenRepo: tokenRepo,
+		sessions:  sessions,
+		mailer:    mailer,
+		resetURL:  c.GetEmail().GetResetUrl(),
+		resetTTL:  defaultPasswordResetTTL,
+		cooldown:  defaultPasswordResetCooldown,
+		queue:     make(chan string, resetQueueSize),
+		log:       log.NewHelper(logger),
+	}
+	if ttl := c.GetEmail().GetResetTtl(); ttl != nil {
+		rc.resetTTL = ttl.AsDuration()
+	}
+	if d := c.GetEmail().GetResetCooldown(); d != nil {
+		rc.cooldown = d.AsDuration()
+	}
+	return rc
+}
+
+// Request 向账号已验证的邮箱发送重置链接；为避免探测账号，请求排队后由Serve处理，
+// 无论账号是否存在、处理是否成功都立即返回成功
+func (rc *PasswordResetUseCase) Request(ctx context.Context, account string) error {
+	select {
+	case rc.queue <- account:
+	default:
+		rc.log.WithContext(ctx).Warn("重置密码请求排队已满，丢弃本次请求")
+	}
+	return nil
+}
+
+// Serve 逐个处理排队的重置请求，stop关闭后处理完已排队的请求再返回
+func (rc *PasswordResetUseCase) Serve(stop <-chan struct{}) {
+	for {
+		select {
+		case account := <-rc.queue:
+			rc.handleRequest(account)
+		case <-stop:
+			for {
+				select {
+				case account := <-rc.queue:
+					rc.handleRequest(account)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (rc *PasswordResetUseCase) handleRequest(account string) {
+	ctx, cancel := context.WithTimeout(context.Background(), resetMailTimeout)
+	defer cancel()
+	if err := rc.sendResetMail(ctx, account); err != nil {
+		rc.log.Errorf("发送重置密码邮件失败: %v", err)
+	}
+}
+
+func (rc *PasswordResetUseCase) sendResetMail(ctx context.Context, account string) error {
+	u, err := rc.findAccount(ctx, account)
+	if err != nil {
+		if errors.Is(err, ex.UserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if u.Email == nil || u.EmailVerifiedAt == nil || u.StatusErr() != nil {
+		rc.log.WithContext(ctx).Infof("用户id=%d没有可用的已验证邮箱或账号不可用，不发送重置邮件", u.Id)
+		return nil
+	}
+
+	// 冷却期内不重复发送，避免邮箱被刷
+	allowed, err := rc.tokenRepo.Cooldown(ctx, tokenPurposePasswordReset, u.Id, rc.cooldown)
+	if err != nil {
+		return fmt.Errorf("用户id=%d检查重置邮件发送间隔失败: %w", u.Id, err)
+	}
+	if !allowed {
+		rc.log.WithContext(ctx).Infof("用户id=%d的重置邮件仍在冷却期内，不重复发送", u.Id)
+		return nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return err
+	}
+	// token中带上用户id用于定位存储，只保存随机部分的哈希
+	random := base64.RawURLEncoding.EncodeToString(secret)
+	token := strconv.FormatInt(u.Id, 10) + "." + random
+	if err = rc.tokenRepo.Save(ctx, tokenPurposePasswordReset, u.Id, hashToken(random), rc.resetTTL); err != nil {
+		return fmt.Errorf("用户id=%d保存重置token失败: %w", u.Id, err)
+	}
+
+	mail := &Mail{
+		To:      *u.Email,
+		Subject: "重置密码",
+		Body: fmt.Sprintf("您好，%s：\n\n请在%d分钟内点击以下链接重置密码：\n%s\n\n如非本人操作，请忽略本邮件，您的密码不会被修改。",
+			*u.Username, int(rc.resetTTL.Minutes()), fmt.Sprintf(rc.resetURL, token)),
+	}
+	if err = rc.mailer.Send(ctx, mail); err != nil {
+		return fmt.Errorf("用户id=%d的重置密码邮件发送失败: %w", u.Id, err)
+	}
+	return nil
+}
+
+// Reset 校验token并设置新密码，成功后token失效并吊销该用户全部refresh token；
+// 已签发的access token在过期前仍然有效
+func (rc *PasswordResetUseCase) Reset(ctx context.Context, token, password string) error {
+	uid, random, ok := strings.Cut(token, ".")
+	if !ok {
+		return ex.ResetTokenInvalid
+	}
+	userId, err := strconv.ParseInt(uid, 10, 64)
+	if err != nil {
+		return ex.ResetTokenInvalid
+	}
+
+	// 先校验密码强度，不符合要求时token仍可继续使用
+	u := &User{Id: userId, Password: &password}
+	if err = rc.uc.checkPassword(ctx, u); err != nil {
+		if errors.Is(err, ex.UserNotFound) {
+			return ex.ResetTokenInvalid
+		}
+		return err
+	}
+
+	ok, err = rc.tokenRepo.Consume(ctx, tokenPurposePasswordReset, userId, hashToken(random))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ex.ResetTokenInvalid
+	}
+
+	if err = rc.uc.hashPassword(u); err != nil {
+		return err
+	}
+	if err = rc.uc.r.UpdatePassword(ctx, userId, *u.Password); err != nil {
+		return err
+	}
+	if err = rc.sessions.RevokeAll(ctx, userId); err != nil {
+		// 密码已修改，吊销失败只记录日志
+		rc.log.WithContext(ctx).Errorf("用户id=%d重置密码后吊销refresh token失败: %v", userId, err)
+	}
+	rc.log.WithContext(ctx).Infof("用户id=%d已重置密码", userId)
+	return nil
+}
+
+// findAccount 含@时按邮箱查找，否则按用户名查找
+func (rc *PasswordResetUseCase) findAccount(ctx context.Context, account string) (*User, error) {
+	if strings.Contains(account, "@") {
+		return rc.uc.r.GetByEmail(ctx, NormalizeEmail(account))
+	}
+	return rc.uc.r.GetByUsername(ctx, account)
+}
@@ -0,0 +1,86 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	ex "user-service/internal/pkg/errors"
+)
+
+const (
+	PermissionUserUpdate = "user:update"
+	PermissionUserDelete = "user:delete"
+	PermissionUserFreeze = "user:freeze"
+	PermissionUserUnlock = "user:unlock"
+	PermissionRoleGrant  = "role:grant"
+)
+
+type Role struct {
+	Id          int64
+	Name        string
+	Description *string
+	CreatedAt   *time.Time
+}
+
+type Permission struct {
+	Id          int64
+	Code        string
+	Description *string
+}
+
+type RoleRepo interface {
+	ListPermissionCodes(ctx context.Context, userId int64) ([]string, error)
+	GrantRole(ctx context.Context, userId int64, role string) error
+	RevokeRole(ctx context.Context, userId int64, role string) error
+}
+
+// Authorizer 校验调用者是否拥有所需的权限
+type Authorizer struct {
+	r   RoleRepo
+	log *log.Helper
+}
+
+func NewAuthorizer(r RoleRepo, logger log.Logger) *Authorizer {
+	return &Authorizer{r: r, log: log.NewHelper(logger)}
+}
+
+func (a *Authorizer) Authorize(ctx context.Context, permission string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ex.Unauthenticated
+	}
+	codes, err := a.r.ListPermissionCodes(ctx, p.UserId)
+	if err != nil {
+		return err
+	}
+	for _, code := range codes {
+		if code == permission {
+			return nil
+		}
+	}
+	a.log.WithContext(ctx).Infof("用户id=%d缺少权限%s", p.UserId, permission)
+	return ex.PermissionDenied.WithMetadata(map[string]string{"permission": permission})
+}
+
+type RoleUseCase struct {
+	r   RoleRepo
+	tx  Transaction
+	log *log.Helper
+}
+
+func NewRoleUseCase(r RoleRepo, tx Transaction, logger log.Logger) *RoleUseCase {
+	return &RoleUseCase{r: r, tx: tx, log: log.NewHelper(logger)}
+}
+
+func (uc *RoleUseCase) GrantRole(ctx context.Context, userId int64, role string) error {
+	return uc.tx.ExecTx(ctx, func(ctx context.Context) error {
+		return uc.r.GrantRole(ctx, userId, role)
+	})
+}
+
+func (uc *RoleUseCase) RevokeRole(ctx context.Context, userId int64, role string) error {
+	return uc.tx.ExecTx(ctx, func(ctx context.Context) error {
+		return uc.r.RevokeRole(ctx, userId, role)
+	})
+}
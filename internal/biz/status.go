@@ -0,0 +1,58 @@
+package biz
+
+import ex "user-service/internal/pkg/errors"
+
+type UserStatus string
+
+const (
+	UserStatusPending  UserStatus = "pending"
+	UserStatusActive   UserStatus = "active"
+	UserStatusFrozen   UserStatus = "frozen"
+	UserStatusDisabled UserStatus = "disabled"
+)
+
+// Values 供ent生成枚举字段使用
+func (UserStatus) Values() []string {
+	return []string{
+		string(UserStatusPending),
+		string(UserStatusActive),
+		string(UserStatusFrozen),
+		string(UserStatusDisabled),
+	}
+}
+
+// 账号状态的合法迁移：pending → active → frozen/disabled → active
+var userStatusTransitions = map[UserStatus][]UserStatus{
+	UserStatusPending:  {UserStatusActive},
+	UserStatusActive:   {UserStatusFrozen, UserStatusDisabled},
+	UserStatusFrozen:   {UserStatusActive},
+	UserStatusDisabled: {UserStatusActive},
+}
+
+func (s UserStatus) CanTransitionTo(to UserStatus) bool {
+	for _, next := range userStatusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Err 账号处于该状态时不可读取
+func (s UserStatus) Err() error {
+	switch s {
+	case UserStatusFrozen:
+		return ex.UserIsFreeze
+	case UserStatusDisabled:
+		return ex.UserIsDisabled
+	}
+	return nil
+}
+
+// LoginErr 账号处于该状态时不可登录
+func (s UserStatus) LoginErr() error {
+	if s == UserStatusPending {
+		return ex.UserIsPending
+	}
+	return s.Err()
+}
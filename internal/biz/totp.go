@@ -0,0 +1,247 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/conf"
+	"user-service/internal/pkg/aead"
+	ex "user-service/internal/pkg/errors"
+	"user-service/internal/pkg/totp"
+)
+
+const (
+	defaultTotpIssuer        = "user-service"
+	defaultRecoveryCodeCount = 10
+	// 允许验证器与服务器之间相差一个时间步
+	totpSkew = 1
+)
+
+type UserTotp struct {
+	Id     int64
+	UserId int64
+	// Secret 加密后的密钥
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep *int64
+	CreatedAt    *time.Time
+}
+
+type RecoveryCode struct {
+	Id        int64
+	UserId    int64
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt *time.Time
+}
+
+type TotpRepo interface {
+	// GetByUserId 未绑定时返回ex.TotpNotEnrolled
+	GetByUserId(ctx context.Context, userId int64) (*UserTotp, error)
+	// Save 覆盖用户已有的绑定
+	Save(ctx context.Context, t *UserTotp) error
+	Confirm(ctx context.Context, userId int64) error
+	// UseStep 仅当step大于上次使用的时间步时更新并返回true
+	UseStep(ctx context.Context, userId, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userId int64, hashes []string) error
+	// UseRecoveryCode 将未使用的恢复码标记为已使用，恢复码不存在或已使用时返回false
+	UseRecoveryCode(ctx context.Context, userId int64, hash string) (bool, error)
+}
+
+// TotpEnrollment 绑定时返回给用户的密钥，仅此一次可见
+type TotpEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type TotpUseCase struct {
+	r                 TotpRepo
+	tx                Transaction
+	cipher            *aead.Cipher
+	issuer            string
+	recoveryCodeCount int
+	log               *log.Helper
+}
+
+func NewTotpUseCase(c *conf.Auth, r TotpRepo, tx Transaction, logger log.Logger) (*TotpUseCase, error) {
+	uc := &TotpUseCase{
+		r:                 r,
+		tx:                tx,
+		issuer:            c.GetMfa().GetIssuer(),
+		recoveryCodeCount: int(c.GetMfa().GetRecoveryCodes()),
+		log:               log.NewHelper(logger),
+	}
+	if uc.issuer == "" {
+		uc.issuer = defaultTotpIssuer
+	}
+	if uc.recoveryCodeCount <= 0 {
+		uc.recoveryCodeCount = defaultRecoveryCodeCount
+	}
+	// 未配置加密密钥时不开放绑定
+	if key := c.GetMfa().GetEncryptionKey(); key != "" {
+		cipher, err := aead.NewFromBase64(key)
+		if err != nil {
+			return nil, err
+		}
+		uc.cipher = cipher
+	}
+	return uc, nil
+}
+
+// Enabled 用户是否已启用两步验证
+func (uc *TotpUseCase) Enabled(ctx context.Context, userId int64) (bool, error) {
+	t, err := uc.r.GetByUserId(ctx, userId)
+	if err != nil {
+		if errors.Is(err, ex.TotpNotEnrolled) {
+			return false, nil
+		}
+		return false, err
+	}
+	return t.ConfirmedAt != nil, nil
+}
+
+// Enroll 为当前用户生成新的密钥，确认前不生效，可重复调用
+func (uc *TotpUseCase) Enroll(ctx context.Context) (*TotpEnrollment, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ex.Unauthenticated
+	}
+	if uc.cipher == nil {
+		return nil, ex.MfaNotConfigured
+	}
+	enabled, err := uc.Enabled(ctx, p.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ex.TotpAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := uc.cipher.Seal([]byte(secret), secretAD(p.UserId))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err = uc.tx.ExecTx(ctx, func(ctx context.Context) error {
+		return uc.r.Save(ctx, &UserTotp{UserId: p.UserId, Secret: sealed, CreatedAt: &now})
+	}); err != nil {
+		return nil, err
+	}
+	return &TotpEnrollment{
+		Secret: secret,
+		URI:    totp.URI(uc.issuer, p.Username, secret),
+	}, nil
+}
+
+// Confirm 用验证码确认绑定，返回新生成的恢复码明文，仅此一次可见
+func (uc *TotpUseCase) Confirm(ctx context.Context, code string) ([]string, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ex.Unauthenticated
+	}
+	t, err := uc.r.GetByUserId(ctx, p.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if t.ConfirmedAt != nil {
+		return nil, ex.TotpAlreadyEnabled
+	}
+	if ok, err = uc.verifyCode(ctx, t, code); err != nil || !ok {
+		return nil, ex.InvalidMfaCode
+	}
+
+	codes, hashes, err := uc.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.tx.ExecTx(ctx, func(ctx context.Context) error {
+		if err := uc.r.Confirm(ctx, p.UserId); err != nil {
+			return err
+		}
+		return uc.r.ReplaceRecoveryCodes(ctx, p.UserId, hashes)
+	}); err != nil {
+		return nil, err
+	}
+	uc.log.WithContext(ctx).Infof("用户id=%d已启用两步验证", p.UserId)
+	return codes, nil
+}
+
+// Verify 校验登录时提交的验证码，也可以是未使用过的恢复码
+func (uc *TotpUseCase) Verify(ctx context.Context, userId int64, code string) (bool, error) {
+	t, err := uc.r.GetByUserId(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+	if t.ConfirmedAt == nil {
+		return false, ex.TotpNotEnrolled
+	}
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return uc.verifyCode(ctx, t, code)
+	}
+	ok, err := uc.r.UseRecoveryCode(ctx, userId, hashRecoveryCode(code))
+	if ok {
+		uc.log.WithContext(ctx).Warnf("用户id=%d使用了恢复码登录", userId)
+	}
+	return ok, err
+}
+
+func (uc *TotpUseCase) verifyCode(ctx context.Context, t *UserTotp, code string) (bool, error) {
+	if uc.cipher == nil {
+		return false, ex.MfaNotConfigured
+	}
+	secret, err := uc.cipher.Open(t.Secret, secretAD(t.UserId))
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("用户id=%d的TOTP密钥无法解密: %v", t.UserId, err)
+		return false, err
+	}
+	step, ok := totp.Validate(string(secret), code, time.Now(), totpSkew)
+	if !ok {
+		return false, nil
+	}
+	// 同一验证码只能使用一次
+	return uc.r.UseStep(ctx, t.UserId, step)
+}
+
+// newRecoveryCodes 生成形如XXXXX-XXXXX的恢复码及其哈希
+func (uc *TotpUseCase) newRecoveryCodes() ([]string, []string, error) {
+	const alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codes := make([]string, 0, uc.recoveryCodeCount)
+	hashes := make([]string, 0, uc.recoveryCodeCount)
+	b := make([]byte, 10)
+	for i := 0; i < uc.recoveryCodeCount; i++ {
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		for j := range b {
+			b[j] = alphabet[int(b[j])%len(alphabet)]
+		}
+		code := string(b[:5]) + "-" + string(b[5:])
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode 恢复码熵足够高，直接使用SHA-256，便于按哈希查询
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// secretAD 将密文与用户绑定，防止密文被挪用到其他用户
+func secretAD(userId int64) []byte {
+	return []byte("totp:" + strconv.FormatInt(userId, 10))
+}
@@ -2,65 +2,400 @@ package biz
 
 import (
 	"context"
+	"errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"strings"
 	"time"
+	"user-service/internal/pkg/cursor"
+	ex "user-service/internal/pkg/errors"
 )
 
 type User struct {
-	Id        int64
-	Username  *string
-	Password  *string
-	CreatedAt *time.Time
-	UpdatedAt *time.Time
+	Id              int64
+	Username        *string
+	UsernameKey     *string
+	Password        *string
+	Email           *string
+	EmailVerifiedAt *time.Time
+	Nickname        *string
+	AvatarUrl       *string
+	Phone           *string
+	Locale          *string
+	Timezone        *string
+	Bio             *string
+	Attributes      map[string]interface{}
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+	Status          *UserStatus
+	StatusReason    *string
+	StatusChangedBy *int64
+	StatusChangedAt *time.Time
+	DeletedAt       *time.Time
+	// Version 修改时为期望的版本号，为空时不校验
+	Version *int64
+}
+
+// StatusErr 账号状态不允许读取时返回对应错误，未查询状态时视为正常
+func (u *User) StatusErr() error {
+	if u.Status == nil {
+		return nil
+	}
+	return u.Status.Err()
+}
+
+// 可对外返回的用户字段，password等敏感字段不在其中
+const (
+	UserFieldId        = "id"
+	UserFieldUsername  = "username"
+	UserFieldCreatedAt = "created_at"
+	UserFieldUpdatedAt = "updated_at"
+	UserFieldStatus    = "status"
+	UserFieldEmail     = "email"
+	// UserFieldEmailVerified 对应email_verified_at，对外只返回是否已验证
+	UserFieldEmailVerified = "email_verified"
+	UserFieldNickname      = "nickname"
+	UserFieldAvatarUrl     = "avatar_url"
+	UserFieldPhone         = "phone"
+	UserFieldLocale        = "locale"
+	UserFieldTimezone      = "timezone"
+	UserFieldBio           = "bio"
+	UserFieldAttributes    = "attributes"
+	UserFieldVersion       = "version"
+	// UserFieldPassword 只可修改，不可读取
+	UserFieldPassword = "password"
+)
+
+var UserFields = []string{
+	UserFieldId,
+	UserFieldUsername,
+	UserFieldCreatedAt,
+	UserFieldUpdatedAt,
+	UserFieldStatus,
+	UserFieldEmail,
+	UserFieldEmailVerified,
+	UserFieldNickname,
+	UserFieldAvatarUrl,
+	UserFieldPhone,
+	UserFieldLocale,
+	UserFieldTimezone,
+	UserFieldBio,
+	UserFieldAttributes,
+	UserFieldVersion,
+}
+
+// UserUpdatableFields 可通过UpdateUser修改的字段
+var UserUpdatableFields = []string{
+	UserFieldUsername,
+	UserFieldPassword,
+	UserFieldEmail,
+	UserFieldNickname,
+	UserFieldAvatarUrl,
+	UserFieldPhone,
+	UserFieldLocale,
+	UserFieldTimezone,
+	UserFieldBio,
+	UserFieldAttributes,
+}
+
+// ParseUserFields 校验调用方请求的字段并去重，未指定时返回全部可见字段
+func ParseUserFields(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return UserFields, nil
+	}
+	fields := []string{UserFieldId}
+	for _, p := range paths {
+		if !isUserField(p) {
+			return nil, ex.InvalidFieldMask.WithMetadata(map[string]string{"field": p})
+		}
+		if !containsField(fields, p) {
+			fields = append(fields, p)
+		}
+	}
+	return fields, nil
+}
+
+// IsAllUserFields 是否包含全部可见字段，与字段顺序及重复无关
+func IsAllUserFields(fields []string) bool {
+	for _, f := range UserFields {
+		if !containsField(fields, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func isUserField(f string) bool {
+	return containsField(UserFields, f)
+}
+
+// ParseUserUpdateMask 校验UpdateUser的字段掩码，未指定时返回nil，表示只修改非空字段
+func ParseUserUpdateMask(paths []string) ([]string, error) {
+	var fields []string
+	for _, p := range paths {
+		if !containsField(UserUpdatableFields, p) {
+			return nil, ex.InvalidFieldMask.WithMetadata(map[string]string{"field": p})
+		}
+		if !containsField(fields, p) {
+			fields = append(fields, p)
+		}
+	}
+	return fields, nil
+}
+
+func containsField(fields []string, f string) bool {
+	for _, uf := range fields {
+		if uf == f {
+			return true
+		}
+	}
+	return false
+}
+
+// applyUpdateMask 只保留掩码中的字段，返回掩码中未赋值、需要清空的字段
+func applyUpdateMask(u *User, paths []string) ([]string, error) {
+	masked := &User{Id: u.Id, Version: u.Version}
+	var clears []string
+	for _, p := range paths {
+		if u.copyField(masked, p) {
+			continue
+		}
+		// 用户名、密码不能清空
+		if p == UserFieldUsername || p == UserFieldPassword {
+			return nil, ex.InvalidFieldMask.WithMetadata(map[string]string{"field": p})
+		}
+		if !containsField(clears, p) {
+			clears = append(clears, p)
+		}
+	}
+	*u = *masked
+	return clears, nil
+}
+
+// copyField 将可修改的字段f复制到dst，字段未赋值时返回false
+func (u *User) copyField(dst *User, f string) bool {
+	switch f {
+	case UserFieldUsername:
+		dst.Username = u.Username
+		return u.Username != nil
+	case UserFieldPassword:
+		dst.Password = u.Password
+		return u.Password != nil
+	case UserFieldEmail:
+		dst.Email = u.Email
+		return u.Email != nil
+	case UserFieldNickname:
+		dst.Nickname = u.Nickname
+		return u.Nickname != nil
+	case UserFieldAvatarUrl:
+		dst.AvatarUrl = u.AvatarUrl
+		return u.AvatarUrl != nil
+	case UserFieldPhone:
+		dst.Phone = u.Phone
+		return u.Phone != nil
+	case UserFieldLocale:
+		dst.Locale = u.Locale
+		return u.Locale != nil
+	case UserFieldTimezone:
+		dst.Timezone = u.Timezone
+		return u.Timezone != nil
+	case UserFieldBio:
+		dst.Bio = u.Bio
+		return u.Bio != nil
+	case UserFieldAttributes:
+		dst.Attributes = u.Attributes
+		return u.Attributes != nil
+	}
+	return false
+}
+
+// UserListQuery 用户列表的查询条件
+type UserListQuery struct {
+	Page     int
+	PageSize int
+	Username *string
+	Fields   []string
+	// SkipCount 不查询总数，适用于无限滚动等不需要总数的场景
+	SkipCount bool
+	// PageToken 上一次查询返回的游标，指定时忽略Page
+	PageToken string
+	// Cursor 由PageToken解析而来，供仓储层使用
+	Cursor *UserCursor
+}
+
+type UserPage struct {
+	List []*User
+	// Total 跳过计数时为0
+	Total int
+	// HasMore 游标分页向前翻页时，仓储层返回的是前面是否还有数据
+	HasMore       bool
+	NextPageToken string
+	PrevPageToken string
 }
 
 type UserRepo interface {
-	ListUser(ctx context.Context, page, pageSize int, username *string) ([]*User, int, error)
-	GetById(ctx context.Context, id int64) (*User, error)
-	GetUsername(ctx context.Context, id int64) (string, error)
-	GetUsernameBatch(ctx context.Context, ids []int64) (map[int64]string, error)
+	ListUser(ctx context.Context, q *UserListQuery) (*UserPage, error)
+	GetById(ctx context.Context, id int64, fields []string) (*User, error)
+	GetByUsername(ctx context.Context, username string) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	// ExistsUsernameKey 包含已软删除的用户，与唯一索引保持一致
+	ExistsUsernameKey(ctx context.Context, key string) (bool, error)
+	// GetUsername 至少返回用户名及账号状态
+	GetUsername(ctx context.Context, id int64) (*User, error)
+	GetUsernameBatch(ctx context.Context, ids []int64) ([]*User, error)
+	GetStatus(ctx context.Context, id int64) (UserStatus, error)
+	// UpdateStatus 仅当账号当前状态为from时才变更，避免并发变更互相覆盖
+	UpdateStatus(ctx context.Context, id int64, from, to UserStatus, reason string, actor int64) error
 	Save(context.Context, *User) (int64, error)
-	Update(context.Context, *User) error
+	// Update 修改u中非空的字段，并将clears中的字段置为NULL；
+	// u.Version不为空且与当前版本不一致时返回ex.VersionConflict
+	Update(ctx context.Context, u *User, clears []string) error
+	UpdatePassword(ctx context.Context, id int64, password string) error
+	// MarkEmailVerified 仅当用户当前邮箱仍为email时标记为已验证
+	MarkEmailVerified(ctx context.Context, id int64, email string) error
 	Delete(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) error
+	// Purge 物理删除软删除时间早于before的用户，单次最多limit条，返回实际删除的数量
+	Purge(ctx context.Context, before time.Time, limit int) (int, error)
+	// BackfillUsernameKeys 为尚未生成规范化用户名的历史用户回填username_key，与其他账号冲突的跳过
+	BackfillUsernameKeys(ctx context.Context) error
+	// BuildIdFilter 从数据库全量构建用户id过滤器，force为false时已构建则跳过
+	BuildIdFilter(ctx context.Context, force bool) error
 }
 
 type UserUseCase struct {
-	r   UserRepo
-	log *log.Helper
-	tx  Transaction
+	r       UserRepo
+	log     *log.Helper
+	tx      Transaction
+	hasher  PasswordHasher
+	policy  *PasswordPolicy
+	cursors *cursor.Codec
+	// dummyHash 账号不存在时参与校验，使响应时间与账号存在时一致
+	dummyHash string
 }
 
-func NewUserUseCase(r UserRepo, tx Transaction, logger log.Logger) *UserUseCase {
-	return &UserUseCase{r: r, tx: tx, log: log.NewHelper(logger)}
+func NewUserUseCase(r UserRepo, tx Transaction, hasher PasswordHasher, policy *PasswordPolicy, cursors *cursor.Codec, logger log.Logger) *UserUseCase {
+	uc := &UserUseCase{r: r, tx: tx, hasher: hasher, policy: policy, cursors: cursors, log: log.NewHelper(logger)}
+	dummy, err := hasher.Hash(uuid.NewString())
+	if err != nil {
+		uc.log.Fatalf("生成密码哈希失败: %v", err)
+	}
+	uc.dummyHash = dummy
+	return uc
 }
 
-func (uc *UserUseCase) ListUser(ctx context.Context, page, pageSize int, username *string) ([]*User, int, error) {
-	return uc.r.ListUser(ctx, page, pageSize, username)
+// ListUser 指定PageToken时按游标分页，否则按页码分页，两种方式均返回后续翻页的游标
+func (uc *UserUseCase) ListUser(ctx context.Context, q *UserListQuery) (*UserPage, error) {
+	if q.PageToken != "" {
+		c, err := uc.decodeCursor(q.PageToken, q.Username)
+		if err != nil {
+			return nil, err
+		}
+		q.Cursor = c
+	}
+	page, err := uc.r.ListUser(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.setPageTokens(q, page); err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
-func (uc *UserUseCase) GetUserById(ctx context.Context, id int64) (*User, error) {
-	return uc.r.GetById(ctx, id)
+func (uc *UserUseCase) GetUserById(ctx context.Context, id int64, fields []string) (*User, error) {
+	u, err := uc.r.GetById(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	if err = u.StatusErr(); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
 func (uc *UserUseCase) GetUsername(ctx context.Context, id int64) (string, error) {
-	return uc.r.GetUsername(ctx, id)
+	u, err := uc.r.GetUsername(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if err = u.StatusErr(); err != nil {
+		return "", err
+	}
+	return *u.Username, nil
 }
 
+// GetUsernameBatch 冻结、禁用的账号不返回用户名
 func (uc *UserUseCase) GetUsernameBatch(ctx context.Context, ids []int64) (map[int64]string, error) {
-	return uc.r.GetUsernameBatch(ctx, ids)
+	users, err := uc.r.GetUsernameBatch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	nameMap := make(map[int64]string)
+	for _, u := range users {
+		if u.StatusErr() == nil {
+			nameMap[u.Id] = *u.Username
+		}
+	}
+	return nameMap, nil
 }
 
-func (uc *UserUseCase) SaveUser(ctx context.Context, u *User) error {
+// IsUsernameAvailable 按规范化后的用户名判断是否可以注册
+func (uc *UserUseCase) IsUsernameAvailable(ctx context.Context, username string) (bool, error) {
+	key := NormalizeUsername(username)
+	if key == "" {
+		return false, nil
+	}
+	exists, err := uc.r.ExistsUsernameKey(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// SaveUser 填写了邮箱的账号需完成邮箱验证后才能登录，返回新用户的id
+func (uc *UserUseCase) SaveUser(ctx context.Context, u *User) (int64, error) {
+	if err := uc.checkPassword(ctx, u); err != nil {
+		return 0, err
+	}
+	if err := uc.hashPassword(u); err != nil {
+		return 0, err
+	}
+	setUsernameKey(u)
+	normalizeEmail(u)
+	status := UserStatusActive
+	if u.Email != nil {
+		status = UserStatusPending
+	}
+	u.Status = &status
 	id, err := uc.r.Save(ctx, u)
 	// 打印一条普通（非trace）日志
 	uc.log.Infof("新增的用户id=%d", id)
-	return err
+	return id, err
 }
 
-func (uc *UserUseCase) UpdateUser(ctx context.Context, u *User) error {
+// UpdateUser 指定paths时只修改其中的字段，paths中未赋值的字段会被清空；
+// 未指定时只修改u中非空的字段
+func (uc *UserUseCase) UpdateUser(ctx context.Context, u *User, paths []string) error {
+	normalizeProfile(u)
+	var clears []string
+	if len(paths) > 0 {
+		var err error
+		if clears, err = applyUpdateMask(u, paths); err != nil {
+			return err
+		}
+	}
+	if err := uc.checkPassword(ctx, u); err != nil {
+		return err
+	}
+	if err := uc.hashPassword(u); err != nil {
+		return err
+	}
+	setUsernameKey(u)
+	normalizeEmail(u)
 	// 带有事务的操作
 	if e := uc.tx.ExecTx(ctx, func(ctx context.Context) error {
-		return uc.r.Update(ctx, u)
+		return uc.r.Update(ctx, u, clears)
 	}); e != nil {
 		return e
 	}
@@ -70,3 +405,173 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, u *User) error {
 func (uc *UserUseCase) DeleteUser(ctx context.Context, id int64) error {
 	return uc.r.Delete(ctx, id)
 }
+
+func (uc *UserUseCase) RestoreUser(ctx context.Context, id int64) error {
+	return uc.r.Restore(ctx, id)
+}
+
+// PurgeDeletedUsers 分批物理删除超过保留期的已删除用户
+func (uc *UserUseCase) PurgeDeletedUsers(ctx context.Context, retention time.Duration, batchSize int) (int, error) {
+	before := time.Now().Add(-retention)
+	total := 0
+	for {
+		n, err := uc.r.Purge(ctx, before, batchSize)
+		total += n
+		if err != nil || n < batchSize {
+			return total, err
+		}
+	}
+}
+
+// BackfillUsernameKeys 回填历史用户的规范化用户名，已全部回填时只有一次查询
+func (uc *UserUseCase) BackfillUsernameKeys(ctx context.Context) error {
+	return uc.r.BackfillUsernameKeys(ctx)
+}
+
+// RebuildIdFilter 重建用户id过滤器，修复写入失败遗漏的id
+func (uc *UserUseCase) RebuildIdFilter(ctx context.Context, force bool) error {
+	return uc.r.BuildIdFilter(ctx, force)
+}
+
+// ChangeStatus 按状态机变更账号状态，并记录原因及操作人
+func (uc *UserUseCase) ChangeStatus(ctx context.Context, id int64, to UserStatus, reason string) error {
+	var actor int64
+	if p, ok := PrincipalFromContext(ctx); ok {
+		actor = p.UserId
+	}
+	return uc.tx.ExecTx(ctx, func(ctx context.Context) error {
+		from, err := uc.r.GetStatus(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !from.CanTransitionTo(to) {
+			return ex.InvalidStatusTransition.WithMetadata(map[string]string{
+				"from": string(from),
+				"to":   string(to),
+			})
+		}
+		if err = uc.r.UpdateStatus(ctx, id, from, to, reason, actor); err != nil {
+			return err
+		}
+		uc.log.WithContext(ctx).Infof("用户id=%d的状态由%s变更为%s，操作人id=%d，原因：%s", id, from, to, actor, reason)
+		return nil
+	})
+}
+
+// CheckLoginStatus 校验账号当前是否允许登录
+func (uc *UserUseCase) CheckLoginStatus(ctx context.Context, id int64) error {
+	status, err := uc.r.GetStatus(ctx, id)
+	if err != nil {
+		return err
+	}
+	return status.LoginErr()
+}
+
+// VerifyPassword 校验用户名密码，校验通过时若哈希已过时则顺带升级
+func (uc *UserUseCase) VerifyPassword(ctx context.Context, username, password string) (*User, error) {
+	u, err := uc.r.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, ex.UserNotFound) {
+			_, _, _ = uc.hasher.Verify(password, uc.dummyHash)
+			return nil, ex.InvalidCredentials
+		}
+		return nil, err
+	}
+	if u.Password == nil {
+		_, _, _ = uc.hasher.Verify(password, uc.dummyHash)
+		return nil, ex.InvalidCredentials
+	}
+
+	ok, needsRehash, err := uc.hasher.Verify(password, *u.Password)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("用户id=%d的密码哈希无法解析: %v", u.Id, err)
+		return nil, ex.InvalidCredentials
+	}
+	if !ok {
+		return nil, ex.InvalidCredentials
+	}
+	// 密码正确后才暴露账号状态，避免被用于探测账号
+	if u.Status != nil {
+		if err = u.Status.LoginErr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if needsRehash {
+		// 升级失败不影响本次校验，下次校验时会再次尝试
+		if hashed, err := uc.hasher.Hash(password); err == nil {
+			if err = uc.r.UpdatePassword(ctx, u.Id, hashed); err != nil {
+				uc.log.WithContext(ctx).Warnf("用户id=%d的密码哈希升级失败: %v", u.Id, err)
+			}
+		}
+	}
+	return u, nil
+}
+
+// normalizeEmail 邮箱统一存储为去除首尾空白的小写形式
+func normalizeEmail(u *User) {
+	if u.Email == nil {
+		return
+	}
+	email := NormalizeEmail(*u.Email)
+	u.Email = &email
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizeProfile 去除资料字段首尾空白，空字符串视为未赋值，配合字段掩码时即为清空
+func normalizeProfile(u *User) {
+	for _, p := range []**string{&u.Nickname, &u.AvatarUrl, &u.Phone, &u.Locale, &u.Timezone, &u.Bio} {
+		if *p == nil {
+			continue
+		}
+		if v := strings.TrimSpace(**p); v != "" {
+			*p = &v
+		} else {
+			*p = nil
+		}
+	}
+}
+
+func setUsernameKey(u *User) {
+	if u.Username == nil {
+		return
+	}
+	// 空用户名不占用唯一键，由参数校验拦截
+	if key := NormalizeUsername(*u.Username); key != "" {
+		u.UsernameKey = &key
+	}
+}
+
+// checkPassword 按密码策略校验明文密码，需在hashPassword之前调用
+func (uc *UserUseCase) checkPassword(ctx context.Context, u *User) error {
+	if u.Password == nil {
+		return nil
+	}
+	var username string
+	if u.Username != nil {
+		username = *u.Username
+	} else if u.Id != 0 && uc.policy.DisallowUsername() {
+		// 只修改密码时，取当前用户名参与校验
+		cur, err := uc.r.GetUsername(ctx, u.Id)
+		if err != nil {
+			return err
+		}
+		username = *cur.Username
+	}
+	return uc.policy.Check(ctx, *u.Password, username)
+}
+
+func (uc *UserUseCase) hashPassword(u *User) error {
+	if u.Password == nil {
+		return nil
+	}
+	hashed, err := uc.hasher.Hash(*u.Password)
+	if err != nil {
+		return err
+	}
+	u.Password = &hashed
+	return nil
+}
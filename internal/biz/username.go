@@ -0,0 +1,19 @@
+package biz
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+var usernameFolder = cases.Fold()
+
+// NormalizeUsername 生成用户名的唯一键：NFKC规范化、大小写折叠并去除首尾空白，
+// 全角、大小写不同的用户名视为同一个
+func NormalizeUsername(username string) string {
+	key := norm.NFKC.String(username)
+	// 大小写折叠后可能不再是NFKC形式，需再规范化一次
+	key = norm.NFKC.String(usernameFolder.String(key))
+	return strings.TrimSpace(key)
+}
@@ -0,0 +1,208 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/rueian/rueidis"
+	"user-service/internal/conf"
+	"user-service/internal/data/ent"
+	"user-service/internal/data/ent/schema"
+	"user-service/internal/data/ent/user"
+)
+
+// 过滤器相关的key使用相同的hash tag，集群模式下RENAME及lua脚本要求落在同一个slot
+const (
+	userIdBloomKey = "{user:bloom:id}"
+	// 构建期间写入临时key，完成后再替换，避免查询到未构建完的过滤器
+	userIdBloomTmpKey  = userIdBloomKey + ":tmp"
+	userIdBloomLockKey = userIdBloomKey + ":building"
+	// 构建期间每写入一批就续期，实例异常退出后锁在该时间后释放
+	userIdBloomLockTTL = time.Minute
+	userIdBloomBatch   = 1000
+
+	defaultBloomCapacity  = 10000000
+	defaultBloomErrorRate = 0.001
+)
+
+var errUserIdBloomLockLost = errors.New("用户id过滤器构建锁已失效")
+
+// KEYS: 过滤器, 构建中的过滤器  ARGV: 用户id
+// 写入已存在的过滤器，构建期间同时写入两者，避免在替换前后丢失
+var userIdBloomAddScript = rueidis.NewLuaScript(`
+local n = 0
+for _, key in ipairs(KEYS) do
+  if redis.call('EXISTS', key) == 1 then
+    redis.call('BF.ADD', key, ARGV[1])
+    n = n + 1
+  end
+end
+return n
+`)
+
+// KEYS: 锁  ARGV: 持有者token, 有效期(毫秒)
+// 仍由自己持有时续期并返回1，否则返回0
+var extendLockScript = rueidis.NewLuaScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// userIdFilter 基于RedisBloom的已存在用户id过滤器，用于提前拒绝明显不存在的id；
+// 过滤器未就绪、redis故障或熔断时一律放行，由缓存及数据库兜底。
+// 写入失败的id会被误判为不存在，由server.IdFilterServer定期重建修复
+type userIdFilter struct {
+	rds       rueidis.Client
+	db        *ent.Client
+	breaker   circuitbreaker.CircuitBreaker
+	capacity  int64
+	errorRate float64
+	log       *log.Helper
+}
+
+func newUserIdFilter(rds rueidis.Client, db *ent.Client, breaker circuitbreaker.CircuitBreaker, c *conf.Data_Cache_Bloom, logger log.Logger) *userIdFilter {
+	f := &userIdFilter{
+		rds:       rds,
+		db:        db,
+		breaker:   breaker,
+		capacity:  defaultBloomCapacity,
+		errorRate: defaultBloomErrorRate,
+		log:       log.NewHelper(logger),
+	}
+	if c.GetCapacity() > 0 {
+		f.capacity = c.GetCapacity()
+	}
+	if r := c.GetErrorRate(); r > 0 && r < 1 {
+		f.errorRate = r
+	}
+	return f
+}
+
+// MightExist 返回false时id一定不存在
+func (f *userIdFilter) MightExist(ctx context.Context, id int64) bool {
+	if f.breaker.Allow() != nil {
+		return true
+	}
+	resps := f.rds.DoMulti(ctx,
+		f.rds.B().Exists().Key(userIdBloomKey).Build(),
+		f.rds.B().BfExists().Key(userIdBloomKey).Item(strconv.FormatInt(id, 10)).Build(),
+	)
+	ready, err := resps[0].AsInt64()
+	if err != nil {
+		f.breaker.MarkFailed()
+		return true
+	}
+	f.breaker.MarkSuccess()
+	if ready == 0 {
+		return true
+	}
+	exist, err := resps[1].AsInt64()
+	if err != nil {
+		f.log.WithContext(ctx).Warnf("查询用户id过滤器失败: %v", err)
+		return true
+	}
+	return exist == 1
+}
+
+// Add 新用户写入过滤器，需在用户数据提交之后调用；过滤器尚未构建时不创建，由构建时的全量扫描写入
+func (f *userIdFilter) Add(ctx context.Context, id int64) {
+	if f.breaker.Allow() != nil {
+		f.log.WithContext(ctx).Warnf("redis熔断中，用户id%d未写入过滤器", id)
+		return
+	}
+	err := userIdBloomAddScript.Exec(ctx, f.rds,
+		[]string{userIdBloomKey, userIdBloomTmpKey},
+		[]string{strconv.FormatInt(id, 10)},
+	).Error()
+	if err != nil {
+		f.breaker.MarkFailed()
+		f.log.WithContext(ctx).Warnf("用户id%d写入过滤器失败: %v", id, err)
+		return
+	}
+	f.breaker.MarkSuccess()
+}
+
+// Build 从数据库全量构建过滤器，force为false时已存在则跳过；多实例同时执行时只有取得锁的实例构建。
+// 构建期间新增的用户由Add同时写入临时过滤器，替换后不会丢失
+func (f *userIdFilter) Build(ctx context.Context, force bool) error {
+	if !force {
+		ready, err := f.rds.Do(ctx, f.rds.B().Exists().Key(userIdBloomKey).Build()).AsInt64()
+		if err != nil || ready == 1 {
+			return err
+		}
+	}
+
+	token := uuid.NewString()
+	err := f.rds.Do(ctx, f.rds.B().Set().Key(userIdBloomLockKey).Value(token).Nx().PxMilliseconds(userIdBloomLockTTL.Milliseconds()).Build()).Error()
+	if rueidis.IsRedisNil(err) {
+		// 其他实例正在构建
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = unlockScript.Exec(ctx, f.rds, []string{userIdBloomLockKey}, []string{token}).Error()
+	}()
+
+	for _, resp := range f.rds.DoMulti(ctx,
+		f.rds.B().Del().Key(userIdBloomTmpKey).Build(),
+		f.rds.B().BfReserve().Key(userIdBloomTmpKey).ErrorRate(f.errorRate).Capacity(f.capacity).Build(),
+	) {
+		if err = resp.Error(); err != nil {
+			return err
+		}
+	}
+	if err = f.addAll(ctx, userIdBloomTmpKey, token); err != nil {
+		return err
+	}
+	if err = f.rds.Do(ctx, f.rds.B().Rename().Key(userIdBloomTmpKey).Newkey(userIdBloomKey).Build()).Error(); err != nil {
+		return err
+	}
+	f.log.WithContext(ctx).Infof("用户id过滤器构建完成")
+	return nil
+}
+
+// addAll 按id顺序分批写入全部用户id（包含已软删除的），每批写入后续期构建锁，锁已失效时中止
+func (f *userIdFilter) addAll(ctx context.Context, key, token string) error {
+	ctx = schema.SkipSoftDelete(ctx)
+	var after int64
+	for {
+		ids, err := f.db.User.
+			Query().
+			Where(user.IDGT(after)).
+			Order(ent.Asc(user.FieldID)).
+			Limit(userIdBloomBatch).
+			IDs(ctx)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		items := make([]string, 0, len(ids))
+		for _, id := range ids {
+			items = append(items, strconv.FormatInt(id, 10))
+		}
+		if err = f.rds.Do(ctx, f.rds.B().BfMadd().Key(key).Item(items...).Build()).Error(); err != nil {
+			return err
+		}
+		held, err := extendLockScript.Exec(ctx, f.rds,
+			[]string{userIdBloomLockKey},
+			[]string{token, strconv.FormatInt(userIdBloomLockTTL.Milliseconds(), 10)},
+		).AsInt64()
+		if err != nil {
+			return err
+		}
+		if held == 0 {
+			return errUserIdBloomLockLost
+		}
+		after = ids[len(ids)-1]
+	}
+}
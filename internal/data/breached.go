@@ -0,0 +1,139 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/biz"
+	"user-service/internal/conf"
+)
+
+// 单行的最大长度，40位哈希加上":次数"后缀足够
+const breachedLineMax = 128
+
+var errBreachedListFormat = errors.New("泄露密码列表格式错误")
+
+// breachedPasswordRepo 在按哈希升序排列的泄露密码文件中二分查找，不将列表载入内存
+type breachedPasswordRepo struct {
+	f    *os.File
+	size int64
+}
+
+func NewBreachedPasswordRepo(c *conf.Auth, logger log.Logger) (biz.BreachedPasswordRepo, error) {
+	r := &breachedPasswordRepo{}
+	file := c.GetPassword().GetPolicy().GetBreachedList()
+	if file == "" {
+		return r, nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	r.f, r.size = f, fi.Size()
+	// 启动时检查首行，避免配置了格式不符的文件
+	if r.size > 0 {
+		line, _, err := r.lineAt(0)
+		if err == nil && parseBreachedHash(line) == nil {
+			err = errBreachedListFormat
+		}
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+	}
+	log.NewHelper(logger).Infof("已打开泄露密码列表%s，大小%d字节", file, r.size)
+	return r, nil
+}
+
+func (r *breachedPasswordRepo) Contains(_ context.Context, password string) (bool, error) {
+	if r.f == nil {
+		return false, nil
+	}
+	sum := sha1.Sum([]byte(password))
+	target := []byte(hex.EncodeToString(sum[:]))
+
+	// 查找范围为行首位于[lo, hi)的行，lo始终是某一行的行首
+	lo, hi := int64(0), r.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		line, start, err := r.lineAt(mid)
+		if err != nil {
+			return false, err
+		}
+		if start >= hi {
+			hi = mid
+			continue
+		}
+		h := parseBreachedHash(line)
+		if h == nil {
+			return false, errBreachedListFormat
+		}
+		switch c := bytes.Compare(bytes.ToLower(h), target); {
+		case c == 0:
+			return true, nil
+		case c < 0:
+			lo = start + int64(len(line)) + 1
+		default:
+			hi = mid
+		}
+	}
+	return false, nil
+}
+
+// lineAt 返回行首不早于off的第一行（不含换行符）及其行首位置，没有时行首为文件大小
+func (r *breachedPasswordRepo) lineAt(off int64) ([]byte, int64, error) {
+	start := off
+	buf := make([]byte, breachedLineMax*2)
+	if off > 0 {
+		// 从前一个字节开始读，off恰好是行首时也能识别
+		n, err := r.f.ReadAt(buf, off-1)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, 0, err
+		}
+		i := bytes.IndexByte(buf[:n], '\n')
+		if i < 0 {
+			if off-1+int64(n) >= r.size {
+				return nil, r.size, nil
+			}
+			return nil, 0, errBreachedListFormat
+		}
+		start = off + int64(i)
+	}
+	if start >= r.size {
+		return nil, r.size, nil
+	}
+	n, err := r.f.ReadAt(buf[:breachedLineMax], start)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, err
+	}
+	line := buf[:n]
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	} else if start+int64(n) < r.size {
+		return nil, 0, errBreachedListFormat
+	}
+	return line, start, nil
+}
+
+// parseBreachedHash 取出行中的十六进制哈希，兼容HIBP的"哈希:次数"格式
+func parseBreachedHash(line []byte) []byte {
+	if i := bytes.IndexByte(line, ':'); i >= 0 {
+		line = line[:i]
+	}
+	line = bytes.TrimSpace(line)
+	if len(line) != hex.EncodedLen(sha1.Size) {
+		return nil
+	}
+	return line
+}
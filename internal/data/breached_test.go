@@ -0,0 +1,131 @@
+package data
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/conf"
+)
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// writeBreachedList 按HIBP格式写入升序排列的哈希，返回按哈希排序后的密码
+func writeBreachedList(t *testing.T, passwords []string, sep string, trailing bool) (string, []string) {
+	t.Helper()
+	sorted := append([]string(nil), passwords...)
+	sort.Slice(sorted, func(i, j int) bool { return sha1Hex(sorted[i]) < sha1Hex(sorted[j]) })
+	lines := make([]string, 0, len(sorted))
+	for i, p := range sorted {
+		lines = append(lines, fmt.Sprintf("%s:%d", sha1Hex(p), i+1))
+	}
+	content := strings.Join(lines, sep)
+	if trailing {
+		content += sep
+	}
+	file := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file, sorted
+}
+
+func openBreachedList(t *testing.T, file string) *breachedPasswordRepo {
+	t.Helper()
+	c := &conf.Auth{Password: &conf.Auth_Password{Policy: &conf.Auth_Password_Policy{BreachedList: file}}}
+	r, err := NewBreachedPasswordRepo(c, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := r.(*breachedPasswordRepo)
+	t.Cleanup(func() { _ = repo.f.Close() })
+	return repo
+}
+
+func TestBreachedPasswordRepoContains(t *testing.T) {
+	passwords := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		passwords = append(passwords, fmt.Sprintf("password%d", i))
+	}
+
+	tests := []struct {
+		name      string
+		passwords []string
+		sep       string
+		trailing  bool
+	}{
+		{name: "以换行结尾", passwords: passwords, sep: "\n", trailing: true},
+		{name: "末行没有换行", passwords: passwords, sep: "\n", trailing: false},
+		{name: "CRLF换行", passwords: passwords, sep: "\r\n", trailing: true},
+		{name: "只有一行", passwords: passwords[:1], sep: "\n", trailing: false},
+		{name: "两行", passwords: passwords[:2], sep: "\n", trailing: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, sorted := writeBreachedList(t, tt.passwords, tt.sep, tt.trailing)
+			r := openBreachedList(t, file)
+			ctx := context.Background()
+
+			cases := []struct {
+				name     string
+				password string
+				want     bool
+			}{
+				{name: "首行", password: sorted[0], want: true},
+				{name: "末行", password: sorted[len(sorted)-1], want: true},
+				{name: "不存在", password: "not-in-the-list", want: false},
+			}
+			for _, c := range cases {
+				got, err := r.Contains(ctx, c.password)
+				if err != nil {
+					t.Fatalf("%s: %v", c.name, err)
+				}
+				if got != c.want {
+					t.Errorf("%s: Contains(%q) = %v, want %v", c.name, c.password, got, c.want)
+				}
+			}
+			for _, p := range sorted {
+				if ok, err := r.Contains(ctx, p); err != nil || !ok {
+					t.Errorf("Contains(%q) = %v, %v, want true", p, ok, err)
+				}
+			}
+			for i := 0; i < 200; i++ {
+				p := fmt.Sprintf("missing%d", i)
+				if ok, err := r.Contains(ctx, p); err != nil || ok {
+					t.Errorf("Contains(%q) = %v, %v, want false", p, ok, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBreachedPasswordRepoNotConfigured(t *testing.T) {
+	r, err := NewBreachedPasswordRepo(&conf.Auth{}, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := r.Contains(context.Background(), "password"); err != nil || ok {
+		t.Errorf("Contains() = %v, %v, want false", ok, err)
+	}
+}
+
+func TestNewBreachedPasswordRepoRejectsInvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(file, []byte("not a hash\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := &conf.Auth{Password: &conf.Auth_Password{Policy: &conf.Auth_Password_Policy{BreachedList: file}}}
+	if _, err := NewBreachedPasswordRepo(c, log.DefaultLogger); err == nil {
+		t.Error("NewBreachedPasswordRepo() error = nil, want format error")
+	}
+}
@@ -0,0 +1,492 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/go-kratos/aegis/circuitbreaker/sre"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/rueian/rueidis"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"golang.org/x/sync/singleflight"
+	"user-service/internal/conf"
+)
+
+// errCacheMiss 缓存中没有该key，与redis故障区分开
+var errCacheMiss = errors.New("cache miss")
+
+// 缓存访问结果，作为指标的result标签
+const (
+	cacheResultHit = "hit"
+	// 命中本地缓存
+	cacheResultLocal = "local"
+	// 命中不存在的记录
+	cacheResultNegative = "negative"
+	// 命中，但按XFetch在后台提前刷新
+	cacheResultRefresh = "refresh"
+	cacheResultMiss    = "miss"
+	cacheResultInvalid = "invalid"
+	cacheResultError   = "error"
+	// 熔断器打开，未访问redis
+	cacheResultBypass = "bypass"
+)
+
+const (
+	defaultCacheTTL    = 600 * time.Second
+	defaultNegativeTTL = 30 * time.Second
+	defaultLockTTL     = 3 * time.Second
+	defaultLockWait    = 500 * time.Millisecond
+	lockPollInterval   = 50 * time.Millisecond
+	// 回源的超时时间，回源不随发起请求的调用方取消
+	cacheLoadTimeout = 5 * time.Second
+	// 失效计数的保留时间，须长于回源的超时时间
+	cacheGenTTL = time.Minute
+)
+
+// cacheEntry 缓存中存放的内容，除数据外记录回源耗时及过期时间供XFetch使用
+type cacheEntry struct {
+	Value json.RawMessage `json:"v,omitempty"`
+	// NotFound 数据源中不存在，避免反复回源
+	NotFound bool `json:"n,omitempty"`
+	// Delta 回源耗时(ms)
+	Delta int64 `json:"d"`
+	// Expiry 过期时间(unix ms)
+	Expiry int64 `json:"e"`
+	// local 是否来自本地缓存
+	local bool
+}
+
+// KEYS: 缓存, 失效计数  ARGV: 数据, ttl(ms), 回源前读取的失效计数
+// 回源期间缓存被删除过（失效计数已变化）时不写入，避免旧数据在删除后被写回
+var setIfNotInvalidatedScript = rueidis.NewLuaScript(`
+local gen = redis.call('GET', KEYS[2]) or ''
+if gen ~= ARGV[3] then
+  return 0
+end
+redis.call('JSON.SET', KEYS[1], '.', ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return 1
+`)
+
+// genKey 缓存key的失效计数；以整个缓存key作为hash tag，集群模式下与缓存key落在同一个slot
+func genKey(key string) string {
+	return "{" + key + "}:gen"
+}
+
+// KEYS: 锁  ARGV: 加锁时的token
+var unlockScript = rueidis.NewLuaScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// jsonCache 以RedisJSON存储对象；redis故障时记录到熔断器，熔断期间读写直接跳过redis。
+// 回源时同一key的并发请求在进程内合并为一次，开启分布式锁时跨实例合并；
+// 回源返回notFound时写入短期的空标记；设置localTTL时读取经过rueidis的客户端缓存
+type jsonCache struct {
+	rds         rueidis.Client
+	name        string
+	notFound    error
+	ttl         time.Duration
+	negativeTTL time.Duration
+	localTTL    time.Duration
+	ttlJitter   float64
+	xfetchBeta  float64
+	lock        bool
+	lockTTL     time.Duration
+	lockWait    time.Duration
+	breaker     circuitbreaker.CircuitBreaker
+	group       singleflight.Group
+	access      syncint64.Counter
+	log         *log.Helper
+}
+
+func newJSONCache(rds rueidis.Client, name string, notFound error, c *conf.Data_Cache, logger log.Logger) *jsonCache {
+	access, _ := global.Meter("user-service").SyncInt64().Counter("user_service_cache_access",
+		instrument.WithDescription("缓存访问次数，按结果区分命中、未命中及redis故障"))
+	jc := &jsonCache{
+		rds:         rds,
+		name:        name,
+		notFound:    notFound,
+		ttl:         defaultCacheTTL,
+		negativeTTL: defaultNegativeTTL,
+		localTTL:    c.GetLocalTtl().AsDuration(),
+		ttlJitter:   c.GetTtlJitter(),
+		xfetchBeta:  c.GetXfetchBeta(),
+		lock:        c.GetDistributedLock(),
+		lockTTL:     defaultLockTTL,
+		lockWait:    defaultLockWait,
+		breaker:     sre.NewBreaker(),
+		access:      access,
+		log:         log.NewHelper(logger),
+	}
+	if d := c.GetTtl(); d != nil {
+		jc.ttl = d.AsDuration()
+	}
+	if d := c.GetNegativeTtl(); d != nil {
+		jc.negativeTTL = d.AsDuration()
+	}
+	if d := c.GetLockTtl(); d != nil {
+		jc.lockTTL = d.AsDuration()
+	}
+	if d := c.GetLockWait(); d != nil {
+		jc.lockWait = d.AsDuration()
+	}
+	return jc
+}
+
+// Get 命中时解码到v；命中空标记时返回notFound；未命中或缓存内容无法解码时返回errCacheMiss，
+// redis故障或熔断时返回其他错误
+func (c *jsonCache) Get(ctx context.Context, key string, v interface{}) error {
+	e, err := c.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if e.NotFound {
+		c.record(ctx, cacheResultNegative)
+		return c.notFound
+	}
+	return c.decode(ctx, key, e.Value, v)
+}
+
+// Fetch 读取key并解码到v，未命中时调用load回源并写入缓存；
+// 命中但按XFetch需要提前刷新时，先返回缓存中的数据，在后台回源刷新
+func (c *jsonCache) Fetch(ctx context.Context, key string, v interface{}, load func(ctx context.Context) (interface{}, error)) error {
+	e, err := c.get(ctx, key)
+	if err == nil && e.NotFound {
+		c.record(ctx, cacheResultNegative)
+		return c.notFound
+	}
+	if err == nil {
+		if c.shouldRefresh(e) {
+			c.record(ctx, cacheResultRefresh)
+			go func() {
+				_, _, _ = c.group.Do(key, func() (interface{}, error) {
+					return c.load(detach(ctx), key, load)
+				})
+			}()
+		} else if e.local {
+			c.record(ctx, cacheResultLocal)
+		} else {
+			c.record(ctx, cacheResultHit)
+		}
+		if err = c.decode(ctx, key, e.Value, v); err == nil {
+			return nil
+		}
+	}
+	// redis故障时同样回源，熔断期间不再逐条记录
+	if !errors.Is(err, errCacheMiss) && !errors.Is(err, circuitbreaker.ErrNotAllowed) {
+		c.log.WithContext(ctx).Warnf("读取缓存%s失败，直接回源: %v", key, err)
+	}
+
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		return c.load(detach(ctx), key, load)
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return res.Err
+		}
+		return json.Unmarshal(res.Val.([]byte), v)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// MGet 批量读取，命中的key解码后放入vals中对应位置，未命中或无法解码的key返回其下标；
+// 命中空标记的key两者都不返回。redis故障或熔断时全部按未命中处理
+func (c *jsonCache) MGet(ctx context.Context, keys []string, newV func() interface{}) (vals []interface{}, miss []int) {
+	vals = make([]interface{}, len(keys))
+	if err := c.breaker.Allow(); err != nil {
+		c.record(ctx, cacheResultBypass)
+		for i := range keys {
+			miss = append(miss, i)
+		}
+		return vals, miss
+	}
+	var resps []rueidis.RedisResult
+	if c.localTTL > 0 {
+		cmds := make([]rueidis.CacheableTTL, 0, len(keys))
+		for _, key := range keys {
+			cmds = append(cmds, rueidis.CT(c.rds.B().JsonGet().Key(key).Paths(".").Cache(), c.localTTL))
+		}
+		resps = c.rds.DoMultiCache(ctx, cmds...)
+	} else {
+		cmds := make(rueidis.Commands, 0, len(keys))
+		for _, key := range keys {
+			cmds = append(cmds, c.rds.B().JsonGet().Key(key).Paths(".").Build())
+		}
+		resps = c.rds.DoMulti(ctx, cmds...)
+	}
+	for i, resp := range resps {
+		e, err := c.parse(ctx, keys[i], resp)
+		switch {
+		case err != nil:
+			miss = append(miss, i)
+		case e.NotFound:
+			c.record(ctx, cacheResultNegative)
+		default:
+			v := newV()
+			if c.decode(ctx, keys[i], e.Value, v) != nil {
+				miss = append(miss, i)
+				continue
+			}
+			if e.local {
+				c.record(ctx, cacheResultLocal)
+			} else {
+				c.record(ctx, cacheResultHit)
+			}
+			vals[i] = v
+		}
+	}
+	return vals, miss
+}
+
+// Gens 读取各key当前的失效计数，调用方自行回源前调用，写入时传给Set
+func (c *jsonCache) Gens(ctx context.Context, keys []string) ([]string, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	cmds := make(rueidis.Commands, 0, len(keys))
+	for _, key := range keys {
+		cmds = append(cmds, c.rds.B().Get().Key(genKey(key)).Build())
+	}
+	gens := make([]string, 0, len(keys))
+	for _, resp := range c.rds.DoMulti(ctx, cmds...) {
+		gen, err := resp.ToString()
+		if err != nil && !rueidis.IsRedisNil(err) {
+			c.breaker.MarkFailed()
+			return nil, err
+		}
+		gens = append(gens, gen)
+	}
+	c.breaker.MarkSuccess()
+	return gens, nil
+}
+
+// Set 写入由调用方自行回源的数据，gen为回源前读取的失效计数，delta为回源耗时
+func (c *jsonCache) Set(ctx context.Context, key, gen string, v interface{}, delta time.Duration) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	c.set(ctx, key, gen, &cacheEntry{Value: raw, Delta: delta.Milliseconds()}, c.jitter(c.ttl))
+}
+
+// Del 删除缓存并递增失效计数，使删除前已开始的回源不再写入；不受熔断器限制，避免熔断期间遗留过期数据
+func (c *jsonCache) Del(ctx context.Context, keys ...string) error {
+	cmds := make(rueidis.Commands, 0, len(keys)*3)
+	for _, key := range keys {
+		cmds = append(cmds,
+			c.rds.B().Del().Key(key).Build(),
+			c.rds.B().Incr().Key(genKey(key)).Build(),
+			c.rds.B().Pexpire().Key(genKey(key)).Milliseconds(cacheGenTTL.Milliseconds()).Build(),
+		)
+	}
+	for _, resp := range c.rds.DoMulti(ctx, cmds...) {
+		if err := resp.Error(); err != nil {
+			c.breaker.MarkFailed()
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *jsonCache) get(ctx context.Context, key string) (*cacheEntry, error) {
+	if err := c.breaker.Allow(); err != nil {
+		c.record(ctx, cacheResultBypass)
+		return nil, err
+	}
+	if c.localTTL > 0 {
+		return c.parse(ctx, key, c.rds.DoCache(ctx, c.rds.B().JsonGet().Key(key).Paths(".").Cache(), c.localTTL))
+	}
+	return c.parse(ctx, key, c.rds.Do(ctx, c.rds.B().JsonGet().Key(key).Paths(".").Build()))
+}
+
+// parse 解析JSON.GET的结果，同时记录到熔断器
+func (c *jsonCache) parse(ctx context.Context, key string, resp rueidis.RedisResult) (*cacheEntry, error) {
+	raw, err := resp.ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			c.breaker.MarkSuccess()
+			c.record(ctx, cacheResultMiss)
+			return nil, errCacheMiss
+		}
+		c.breaker.MarkFailed()
+		c.record(ctx, cacheResultError)
+		return nil, err
+	}
+	c.breaker.MarkSuccess()
+	e := &cacheEntry{}
+	if err = json.Unmarshal([]byte(raw), e); err != nil || e.Expiry == 0 {
+		// 旧格式的缓存同样删除后按未命中处理
+		c.invalid(ctx, key, err)
+		return nil, errCacheMiss
+	}
+	e.local = resp.IsCacheHit()
+	return e, nil
+}
+
+func (c *jsonCache) decode(ctx context.Context, key string, raw []byte, v interface{}) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		c.invalid(ctx, key, err)
+		return errCacheMiss
+	}
+	return nil
+}
+
+// invalid 缓存内容损坏或结构已变更，删除后按未命中处理
+func (c *jsonCache) invalid(ctx context.Context, key string, err error) {
+	c.log.WithContext(ctx).Warnf("缓存%s无法解码，已删除: %v", key, err)
+	c.record(ctx, cacheResultInvalid)
+	_ = c.Del(ctx, key)
+}
+
+// load 回源并写入缓存，返回编码后的数据；开启分布式锁时，未取得锁的实例先等待其他实例写入缓存
+func (c *jsonCache) load(ctx context.Context, key string, load func(ctx context.Context) (interface{}, error)) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, cacheLoadTimeout)
+	defer cancel()
+
+	if c.lock && c.breaker.Allow() == nil {
+		unlock, ok := c.tryLock(ctx, key)
+		if ok {
+			defer unlock()
+		} else if e, ok := c.waitFill(ctx, key); ok {
+			if e.NotFound {
+				return nil, c.notFound
+			}
+			return e.Value, nil
+		}
+	}
+
+	// 读取失败时本次回源的结果不写入缓存
+	gens, genErr := c.Gens(ctx, []string{key})
+	start := time.Now()
+	v, err := load(ctx)
+	if err != nil {
+		if genErr == nil && c.notFound != nil && c.negativeTTL > 0 && errors.Is(err, c.notFound) {
+			c.set(ctx, key, gens[0], &cacheEntry{NotFound: true}, c.negativeTTL)
+		}
+		return nil, err
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if genErr == nil {
+		c.set(ctx, key, gens[0], &cacheEntry{Value: raw, Delta: time.Since(start).Milliseconds()}, c.jitter(c.ttl))
+	}
+	return raw, nil
+}
+
+func (c *jsonCache) set(ctx context.Context, key, gen string, e *cacheEntry, ttl time.Duration) {
+	if c.breaker.Allow() != nil {
+		return
+	}
+	e.Expiry = time.Now().Add(ttl).UnixMilli()
+	val, _ := json.Marshal(e)
+	err := setIfNotInvalidatedScript.Exec(ctx, c.rds,
+		[]string{key, genKey(key)},
+		[]string{string(val), strconv.FormatInt(ttl.Milliseconds(), 10), gen},
+	).Error()
+	if err != nil {
+		c.breaker.MarkFailed()
+		c.log.WithContext(ctx).Warnf("写入缓存%s失败: %v", key, err)
+		return
+	}
+	c.breaker.MarkSuccess()
+}
+
+// shouldRefresh XFetch：越接近过期、回源越慢，越可能提前刷新，避免热点key同时过期后集中回源
+func (c *jsonCache) shouldRefresh(e *cacheEntry) bool {
+	if c.xfetchBeta <= 0 {
+		return false
+	}
+	gap := -float64(e.Delta) * c.xfetchBeta * math.Log(rand.Float64())
+	return float64(time.Now().UnixMilli())+gap >= float64(e.Expiry)
+}
+
+// jitter 过期时间随机浮动，避免同时写入的key同时过期
+func (c *jsonCache) jitter(ttl time.Duration) time.Duration {
+	if c.ttlJitter <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration((rand.Float64()*2-1)*c.ttlJitter*float64(ttl))
+}
+
+func (c *jsonCache) tryLock(ctx context.Context, key string) (func(), bool) {
+	lockKey := key + ":lock"
+	token := uuid.NewString()
+	err := c.rds.Do(ctx, c.rds.B().Set().Key(lockKey).Value(token).Nx().PxMilliseconds(c.lockTTL.Milliseconds()).Build()).Error()
+	if err != nil {
+		// 锁已被其他实例持有时返回nil
+		if !rueidis.IsRedisNil(err) {
+			c.log.WithContext(ctx).Warnf("获取缓存锁%s失败: %v", lockKey, err)
+		}
+		return nil, false
+	}
+	return func() {
+		if err := unlockScript.Exec(ctx, c.rds, []string{lockKey}, []string{token}).Error(); err != nil {
+			c.log.WithContext(ctx).Warnf("释放缓存锁%s失败: %v", lockKey, err)
+		}
+	}, true
+}
+
+// waitFill 等待持有锁的实例写入缓存，超时或redis故障时返回false，由调用方自行回源
+func (c *jsonCache) waitFill(ctx context.Context, key string) (*cacheEntry, bool) {
+	deadline := time.Now().Add(c.lockWait)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(lockPollInterval):
+		}
+		e, err := c.get(ctx, key)
+		if err == nil {
+			return e, true
+		}
+		if !errors.Is(err, errCacheMiss) {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func (c *jsonCache) record(ctx context.Context, result string) {
+	if c.access != nil {
+		c.access.Add(ctx, 1, attribute.String("cache", c.name), attribute.String("result", result))
+	}
+}
+
+// detachedContext 保留ctx中的值（如trace），但不随调用方取消，
+// 合并后的回源不应因第一个调用方取消而让其他调用方一起失败
+type detachedContext struct {
+	context.Context
+}
+
+func detach(ctx context.Context) context.Context {
+	return detachedContext{ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
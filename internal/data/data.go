@@ -18,6 +18,8 @@ import (
 	"user-service/internal/biz"
 	"user-service/internal/conf"
 	"user-service/internal/data/ent"
+	// 注册schema中定义的privacy策略（软删除过滤）
+	_ "user-service/internal/data/ent/runtime"
 )
 
 var ProviderSet = wire.NewSet(
@@ -27,6 +29,13 @@ var ProviderSet = wire.NewSet(
 	NewRedis,
 	NewRegistrar,
 	NewUserRepo,
+	NewRefreshTokenRepo,
+	NewRoleRepo,
+	NewBreachedPasswordRepo,
+	NewLoginLockoutRepo,
+	NewTotpRepo,
+	NewMailer,
+	NewOneTimeTokenRepo,
 )
 
 type Data struct {
@@ -50,6 +59,24 @@ func (d *Data) ExecTx(ctx context.Context, f func(ctx context.Context) error) er
 	return tx.Commit()
 }
 
+// AfterCommit 在事务中时f延迟到事务提交成功后执行，回滚时不执行；不在事务中时立即执行
+func (d *Data) AfterCommit(ctx context.Context, f func(ctx context.Context)) {
+	tx, ok := ctx.Value(contextTxKey{}).(*ent.Tx)
+	if !ok {
+		f(ctx)
+		return
+	}
+	tx.OnCommit(func(next ent.Committer) ent.Committer {
+		return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+			if err := next.Commit(ctx, tx); err != nil {
+				return err
+			}
+			f(ctx)
+			return nil
+		})
+	})
+}
+
 func (d *Data) User(ctx context.Context) *ent.UserClient {
 	tx, ok := ctx.Value(contextTxKey{}).(*ent.Tx)
 	if ok {
@@ -58,6 +85,30 @@ func (d *Data) User(ctx context.Context) *ent.UserClient {
 	return d.db.User
 }
 
+func (d *Data) Role(ctx context.Context) *ent.RoleClient {
+	tx, ok := ctx.Value(contextTxKey{}).(*ent.Tx)
+	if ok {
+		return tx.Role
+	}
+	return d.db.Role
+}
+
+func (d *Data) UserTotp(ctx context.Context) *ent.UserTotpClient {
+	tx, ok := ctx.Value(contextTxKey{}).(*ent.Tx)
+	if ok {
+		return tx.UserTotp
+	}
+	return d.db.UserTotp
+}
+
+func (d *Data) RecoveryCode(ctx context.Context) *ent.RecoveryCodeClient {
+	tx, ok := ctx.Value(contextTxKey{}).(*ent.Tx)
+	if ok {
+		return tx.RecoveryCode
+	}
+	return d.db.RecoveryCode
+}
+
 func NewTransaction(d *Data) biz.Transaction {
 	return d
 }
@@ -112,7 +163,7 @@ func NewDB(conf *conf.Data, logger log.Logger) *ent.Client {
 	})
 	client := ent.NewClient(ent.Driver(sqlDrv))
 
-	// 运行自动创建表
+	// 线上按顺序执行migrations目录下的SQL，本地开发可打开下面的自动建表
 	//if err := db.Schema.Create(context.Background(), migrate.WithForeignKeys(false)); err != nil {
 	//	thisLog.Fatalf("创建表失败: %v", err)
 	//}
@@ -127,6 +178,9 @@ func NewRedis(conf *conf.Data, logger log.Logger) rueidis.Client {
 		Password:         conf.Redis.Password,
 		SelectDB:         int(conf.Redis.Db),
 		ConnWriteTimeout: conf.Redis.WriteTimeout.AsDuration(),
+		// 本地缓存依赖RESP3的客户端缓存，由redis推送失效通知
+		DisableCache:      conf.Cache.GetLocalTtl().AsDuration() <= 0,
+		CacheSizeEachConn: int(conf.Cache.GetLocalMaxBytes()),
 	})
 	// todo 等rueidis升级otel版本
 	//client = rueidisotel.WithClient(client)
@@ -28,7 +28,11 @@ type config struct {
 
 // hooks per client, for fast access.
 type hooks struct {
-	User []ent.Hook
+	Permission   []ent.Hook
+	RecoveryCode []ent.Hook
+	Role         []ent.Hook
+	User         []ent.Hook
+	UserTotp     []ent.Hook
 }
 
 // Options applies the options on the config object.
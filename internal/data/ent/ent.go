@@ -6,7 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"user-service/internal/data/ent/permission"
+	"user-service/internal/data/ent/recoverycode"
+	"user-service/internal/data/ent/role"
 	"user-service/internal/data/ent/user"
+	"user-service/internal/data/ent/usertotp"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
@@ -31,7 +35,11 @@ type OrderFunc func(*sql.Selector)
 // columnChecker returns a function indicates if the column exists in the given column.
 func columnChecker(table string) func(string) error {
 	checks := map[string]func(string) bool{
-		user.Table: user.ValidColumn,
+		permission.Table:   permission.ValidColumn,
+		recoverycode.Table: recoverycode.ValidColumn,
+		role.Table:         role.ValidColumn,
+		user.Table:         user.ValidColumn,
+		usertotp.Table:     usertotp.ValidColumn,
 	}
 	check, ok := checks[table]
 	if !ok {
@@ -9,13 +9,75 @@ import (
 )
 
 var (
+	// PermissionColumns holds the columns for the "permission" table.
+	PermissionColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "code", Type: field.TypeString, Unique: true},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+	}
+	// PermissionTable holds the schema information for the "permission" table.
+	PermissionTable = &schema.Table{
+		Name:       "permission",
+		Columns:    PermissionColumns,
+		PrimaryKey: []*schema.Column{PermissionColumns[0]},
+	}
+	// UserRecoveryCodeColumns holds the columns for the "user_recovery_code" table.
+	UserRecoveryCodeColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "user_id", Type: field.TypeInt64},
+		{Name: "code_hash", Type: field.TypeString},
+		{Name: "used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime, Nullable: true},
+	}
+	// UserRecoveryCodeTable holds the schema information for the "user_recovery_code" table.
+	UserRecoveryCodeTable = &schema.Table{
+		Name:       "user_recovery_code",
+		Columns:    UserRecoveryCodeColumns,
+		PrimaryKey: []*schema.Column{UserRecoveryCodeColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "recoverycode_user_id_code_hash",
+				Unique:  true,
+				Columns: []*schema.Column{UserRecoveryCodeColumns[1], UserRecoveryCodeColumns[2]},
+			},
+		},
+	}
+	// RoleColumns holds the columns for the "role" table.
+	RoleColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "name", Type: field.TypeString, Unique: true},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime, Nullable: true},
+	}
+	// RoleTable holds the schema information for the "role" table.
+	RoleTable = &schema.Table{
+		Name:       "role",
+		Columns:    RoleColumns,
+		PrimaryKey: []*schema.Column{RoleColumns[0]},
+	}
 	// UserColumns holds the columns for the "user" table.
 	UserColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
 		{Name: "username", Type: field.TypeString, Nullable: true},
+		{Name: "username_key", Type: field.TypeString, Unique: true, Nullable: true},
 		{Name: "password", Type: field.TypeString, Nullable: true},
+		{Name: "email", Type: field.TypeString, Unique: true, Nullable: true},
+		{Name: "email_verified_at", Type: field.TypeTime, Nullable: true},
+		{Name: "nickname", Type: field.TypeString, Nullable: true},
+		{Name: "avatar_url", Type: field.TypeString, Nullable: true},
+		{Name: "phone", Type: field.TypeString, Nullable: true},
+		{Name: "locale", Type: field.TypeString, Nullable: true},
+		{Name: "timezone", Type: field.TypeString, Nullable: true},
+		{Name: "bio", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "attributes", Type: field.TypeJSON, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime, Nullable: true},
 		{Name: "updated_at", Type: field.TypeTime, Nullable: true},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "active", "frozen", "disabled"}, Default: "active"},
+		{Name: "status_reason", Type: field.TypeString, Nullable: true},
+		{Name: "status_changed_by", Type: field.TypeInt64, Nullable: true},
+		{Name: "status_changed_at", Type: field.TypeTime, Nullable: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "version", Type: field.TypeInt64, Default: 1},
 	}
 	// UserTable holds the schema information for the "user" table.
 	UserTable = &schema.Table{
@@ -23,14 +85,101 @@ var (
 		Columns:    UserColumns,
 		PrimaryKey: []*schema.Column{UserColumns[0]},
 	}
+	// UserTotpColumns holds the columns for the "user_totp" table.
+	UserTotpColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "user_id", Type: field.TypeInt64, Unique: true},
+		{Name: "secret", Type: field.TypeString},
+		{Name: "confirmed_at", Type: field.TypeTime, Nullable: true},
+		{Name: "last_used_step", Type: field.TypeInt64, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime, Nullable: true},
+	}
+	// UserTotpTable holds the schema information for the "user_totp" table.
+	UserTotpTable = &schema.Table{
+		Name:       "user_totp",
+		Columns:    UserTotpColumns,
+		PrimaryKey: []*schema.Column{UserTotpColumns[0]},
+	}
+	// RolePermissionColumns holds the columns for the "role_permission" table.
+	RolePermissionColumns = []*schema.Column{
+		{Name: "role_id", Type: field.TypeInt64},
+		{Name: "permission_id", Type: field.TypeInt64},
+	}
+	// RolePermissionTable holds the schema information for the "role_permission" table.
+	RolePermissionTable = &schema.Table{
+		Name:       "role_permission",
+		Columns:    RolePermissionColumns,
+		PrimaryKey: []*schema.Column{RolePermissionColumns[0], RolePermissionColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "role_permission_role_id",
+				Columns:    []*schema.Column{RolePermissionColumns[0]},
+				RefColumns: []*schema.Column{RoleColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "role_permission_permission_id",
+				Columns:    []*schema.Column{RolePermissionColumns[1]},
+				RefColumns: []*schema.Column{PermissionColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// UserRoleColumns holds the columns for the "user_role" table.
+	UserRoleColumns = []*schema.Column{
+		{Name: "user_id", Type: field.TypeInt64},
+		{Name: "role_id", Type: field.TypeInt64},
+	}
+	// UserRoleTable holds the schema information for the "user_role" table.
+	UserRoleTable = &schema.Table{
+		Name:       "user_role",
+		Columns:    UserRoleColumns,
+		PrimaryKey: []*schema.Column{UserRoleColumns[0], UserRoleColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_role_user_id",
+				Columns:    []*schema.Column{UserRoleColumns[0]},
+				RefColumns: []*schema.Column{UserColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "user_role_role_id",
+				Columns:    []*schema.Column{UserRoleColumns[1]},
+				RefColumns: []*schema.Column{RoleColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		PermissionTable,
+		UserRecoveryCodeTable,
+		RoleTable,
 		UserTable,
+		UserTotpTable,
+		RolePermissionTable,
+		UserRoleTable,
 	}
 )
 
 func init() {
+	PermissionTable.Annotation = &entsql.Annotation{
+		Table: "permission",
+	}
+	UserRecoveryCodeTable.Annotation = &entsql.Annotation{
+		Table: "user_recovery_code",
+	}
+	RoleTable.Annotation = &entsql.Annotation{
+		Table: "role",
+	}
 	UserTable.Annotation = &entsql.Annotation{
 		Table: "user",
 	}
+	UserTotpTable.Annotation = &entsql.Annotation{
+		Table: "user_totp",
+	}
+	RolePermissionTable.ForeignKeys[0].RefTable = RoleTable
+	RolePermissionTable.ForeignKeys[1].RefTable = PermissionTable
+	UserRoleTable.ForeignKeys[0].RefTable = UserTable
+	UserRoleTable.ForeignKeys[1].RefTable = RoleTable
 }
@@ -0,0 +1,136 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"user-service/internal/data/ent/permission"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// Permission is the model entity for the Permission schema.
+type Permission struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Code holds the value of the "code" field.
+	Code string `json:"code,omitempty"`
+	// Description holds the value of the "description" field.
+	Description string `json:"description,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PermissionQuery when eager-loading is set.
+	Edges PermissionEdges `json:"edges"`
+}
+
+// PermissionEdges holds the relations/edges for other nodes in the graph.
+type PermissionEdges struct {
+	// Roles holds the value of the roles edge.
+	Roles []*Role `json:"roles,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// RolesOrErr returns the Roles value or an error if the edge
+// was not loaded in eager-loading.
+func (e PermissionEdges) RolesOrErr() ([]*Role, error) {
+	if e.loadedTypes[0] {
+		return e.Roles, nil
+	}
+	return nil, &NotLoadedError{edge: "roles"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Permission) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case permission.FieldID:
+			values[i] = new(sql.NullInt64)
+		case permission.FieldCode, permission.FieldDescription:
+			values[i] = new(sql.NullString)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type Permission", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Permission fields.
+func (pe *Permission) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case permission.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pe.ID = int64(value.Int64)
+		case permission.FieldCode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field code", values[i])
+			} else if value.Valid {
+				pe.Code = value.String
+			}
+		case permission.FieldDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field description", values[i])
+			} else if value.Valid {
+				pe.Description = value.String
+			}
+		}
+	}
+	return nil
+}
+
+// QueryRoles queries the "roles" edge of the Permission entity.
+func (pe *Permission) QueryRoles() *RoleQuery {
+	return (&PermissionClient{config: pe.config}).QueryRoles(pe)
+}
+
+// Update returns a builder for updating this Permission.
+// Note that you need to call Permission.Unwrap() before calling this method if this Permission
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pe *Permission) Update() *PermissionUpdateOne {
+	return (&PermissionClient{config: pe.config}).UpdateOne(pe)
+}
+
+// Unwrap unwraps the Permission entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pe *Permission) Unwrap() *Permission {
+	_tx, ok := pe.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Permission is not a transactional entity")
+	}
+	pe.config.driver = _tx.drv
+	return pe
+}
+
+// String implements the fmt.Stringer.
+func (pe *Permission) String() string {
+	var builder strings.Builder
+	builder.WriteString("Permission(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pe.ID))
+	builder.WriteString("code=")
+	builder.WriteString(pe.Code)
+	builder.WriteString(", ")
+	builder.WriteString("description=")
+	builder.WriteString(pe.Description)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Permissions is a parsable slice of Permission.
+type Permissions []*Permission
+
+func (pe Permissions) config(cfg config) {
+	for _i := range pe {
+		pe[_i].config = cfg
+	}
+}
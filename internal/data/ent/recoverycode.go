@@ -0,0 +1,134 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"user-service/internal/data/ent/recoverycode"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// RecoveryCode is the model entity for the RecoveryCode schema.
+type RecoveryCode struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int64 `json:"user_id,omitempty"`
+	// CodeHash holds the value of the "code_hash" field.
+	CodeHash string `json:"-"`
+	// UsedAt holds the value of the "used_at" field.
+	UsedAt time.Time `json:"used_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*RecoveryCode) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case recoverycode.FieldID, recoverycode.FieldUserID:
+			values[i] = new(sql.NullInt64)
+		case recoverycode.FieldCodeHash:
+			values[i] = new(sql.NullString)
+		case recoverycode.FieldUsedAt, recoverycode.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type RecoveryCode", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the RecoveryCode fields.
+func (rc *RecoveryCode) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case recoverycode.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			rc.ID = int64(value.Int64)
+		case recoverycode.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				rc.UserID = value.Int64
+			}
+		case recoverycode.FieldCodeHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field code_hash", values[i])
+			} else if value.Valid {
+				rc.CodeHash = value.String
+			}
+		case recoverycode.FieldUsedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field used_at", values[i])
+			} else if value.Valid {
+				rc.UsedAt = value.Time
+			}
+		case recoverycode.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				rc.CreatedAt = value.Time
+			}
+		}
+	}
+	return nil
+}
+
+// Update returns a builder for updating this RecoveryCode.
+// Note that you need to call RecoveryCode.Unwrap() before calling this method if this RecoveryCode
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (rc *RecoveryCode) Update() *RecoveryCodeUpdateOne {
+	return (&RecoveryCodeClient{config: rc.config}).UpdateOne(rc)
+}
+
+// Unwrap unwraps the RecoveryCode entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (rc *RecoveryCode) Unwrap() *RecoveryCode {
+	_tx, ok := rc.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: RecoveryCode is not a transactional entity")
+	}
+	rc.config.driver = _tx.drv
+	return rc
+}
+
+// String implements the fmt.Stringer.
+func (rc *RecoveryCode) String() string {
+	var builder strings.Builder
+	builder.WriteString("RecoveryCode(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", rc.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", rc.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("code_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("used_at=")
+	builder.WriteString(rc.UsedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(rc.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// RecoveryCodes is a parsable slice of RecoveryCode.
+type RecoveryCodes []*RecoveryCode
+
+func (rc RecoveryCodes) config(cfg config) {
+	for _i := range rc {
+		rc[_i].config = cfg
+	}
+}
@@ -0,0 +1,39 @@
+// Code generated by ent, DO NOT EDIT.
+
+package recoverycode
+
+const (
+	// Label holds the string label denoting the recoverycode type in the database.
+	Label = "recovery_code"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldCodeHash holds the string denoting the code_hash field in the database.
+	FieldCodeHash = "code_hash"
+	// FieldUsedAt holds the string denoting the used_at field in the database.
+	FieldUsedAt = "used_at"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the recoverycode in the database.
+	Table = "user_recovery_code"
+)
+
+// Columns holds all SQL columns for recoverycode fields.
+var Columns = []string{
+	FieldID,
+	FieldUserID,
+	FieldCodeHash,
+	FieldUsedAt,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,458 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"user-service/internal/data/ent/predicate"
+	"user-service/internal/data/ent/recoverycode"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// RecoveryCodeUpdate is the builder for updating RecoveryCode entities.
+type RecoveryCodeUpdate struct {
+	config
+	hooks    []Hook
+	mutation *RecoveryCodeMutation
+}
+
+// Where appends a list predicates to the RecoveryCodeUpdate builder.
+func (rcu *RecoveryCodeUpdate) Where(ps ...predicate.RecoveryCode) *RecoveryCodeUpdate {
+	rcu.mutation.Where(ps...)
+	return rcu
+}
+
+// SetUserID sets the "user_id" field.
+func (rcu *RecoveryCodeUpdate) SetUserID(i int64) *RecoveryCodeUpdate {
+	rcu.mutation.ResetUserID()
+	rcu.mutation.SetUserID(i)
+	return rcu
+}
+
+// AddUserID adds i to the "user_id" field.
+func (rcu *RecoveryCodeUpdate) AddUserID(i int64) *RecoveryCodeUpdate {
+	rcu.mutation.AddUserID(i)
+	return rcu
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (rcu *RecoveryCodeUpdate) SetCodeHash(s string) *RecoveryCodeUpdate {
+	rcu.mutation.SetCodeHash(s)
+	return rcu
+}
+
+// SetUsedAt sets the "used_at" field.
+func (rcu *RecoveryCodeUpdate) SetUsedAt(t time.Time) *RecoveryCodeUpdate {
+	rcu.mutation.SetUsedAt(t)
+	return rcu
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (rcu *RecoveryCodeUpdate) SetNillableUsedAt(t *time.Time) *RecoveryCodeUpdate {
+	if t != nil {
+		rcu.SetUsedAt(*t)
+	}
+	return rcu
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (rcu *RecoveryCodeUpdate) ClearUsedAt() *RecoveryCodeUpdate {
+	rcu.mutation.ClearUsedAt()
+	return rcu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (rcu *RecoveryCodeUpdate) SetCreatedAt(t time.Time) *RecoveryCodeUpdate {
+	rcu.mutation.SetCreatedAt(t)
+	return rcu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (rcu *RecoveryCodeUpdate) SetNillableCreatedAt(t *time.Time) *RecoveryCodeUpdate {
+	if t != nil {
+		rcu.SetCreatedAt(*t)
+	}
+	return rcu
+}
+
+// ClearCreatedAt clears the value of the "created_at" field.
+func (rcu *RecoveryCodeUpdate) ClearCreatedAt() *RecoveryCodeUpdate {
+	rcu.mutation.ClearCreatedAt()
+	return rcu
+}
+
+// Mutation returns the RecoveryCodeMutation object of the builder.
+func (rcu *RecoveryCodeUpdate) Mutation() *RecoveryCodeMutation {
+	return rcu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (rcu *RecoveryCodeUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(rcu.hooks) == 0 {
+		affected, err = rcu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*RecoveryCodeMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			rcu.mutation = mutation
+			affected, err = rcu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(rcu.hooks) - 1; i >= 0; i-- {
+			if rcu.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = rcu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, rcu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rcu *RecoveryCodeUpdate) SaveX(ctx context.Context) int {
+	affected, err := rcu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (rcu *RecoveryCodeUpdate) Exec(ctx context.Context) error {
+	_, err := rcu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rcu *RecoveryCodeUpdate) ExecX(ctx context.Context) {
+	if err := rcu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (rcu *RecoveryCodeUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   recoverycode.Table,
+			Columns: recoverycode.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: recoverycode.FieldID,
+			},
+		},
+	}
+	if ps := rcu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := rcu.mutation.UserID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: recoverycode.FieldUserID,
+		})
+	}
+	if value, ok := rcu.mutation.AddedUserID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: recoverycode.FieldUserID,
+		})
+	}
+	if value, ok := rcu.mutation.CodeHash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: recoverycode.FieldCodeHash,
+		})
+	}
+	if value, ok := rcu.mutation.UsedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: recoverycode.FieldUsedAt,
+		})
+	}
+	if rcu.mutation.UsedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: recoverycode.FieldUsedAt,
+		})
+	}
+	if value, ok := rcu.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: recoverycode.FieldCreatedAt,
+		})
+	}
+	if rcu.mutation.CreatedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: recoverycode.FieldCreatedAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, rcu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{recoverycode.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// RecoveryCodeUpdateOne is the builder for updating a single RecoveryCode entity.
+type RecoveryCodeUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *RecoveryCodeMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (rcuo *RecoveryCodeUpdateOne) SetUserID(i int64) *RecoveryCodeUpdateOne {
+	rcuo.mutation.ResetUserID()
+	rcuo.mutation.SetUserID(i)
+	return rcuo
+}
+
+// AddUserID adds i to the "user_id" field.
+func (rcuo *RecoveryCodeUpdateOne) AddUserID(i int64) *RecoveryCodeUpdateOne {
+	rcuo.mutation.AddUserID(i)
+	return rcuo
+}
+
+// SetCodeHash sets the "code_hash" field.
+func (rcuo *RecoveryCodeUpdateOne) SetCodeHash(s string) *RecoveryCodeUpdateOne {
+	rcuo.mutation.SetCodeHash(s)
+	return rcuo
+}
+
+// SetUsedAt sets the "used_at" field.
+func (rcuo *RecoveryCodeUpdateOne) SetUsedAt(t time.Time) *RecoveryCodeUpdateOne {
+	rcuo.mutation.SetUsedAt(t)
+	return rcuo
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (rcuo *RecoveryCodeUpdateOne) SetNillableUsedAt(t *time.Time) *RecoveryCodeUpdateOne {
+	if t != nil {
+		rcuo.SetUsedAt(*t)
+	}
+	return rcuo
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (rcuo *RecoveryCodeUpdateOne) ClearUsedAt() *RecoveryCodeUpdateOne {
+	rcuo.mutation.ClearUsedAt()
+	return rcuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (rcuo *RecoveryCodeUpdateOne) SetCreatedAt(t time.Time) *RecoveryCodeUpdateOne {
+	rcuo.mutation.SetCreatedAt(t)
+	return rcuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (rcuo *RecoveryCodeUpdateOne) SetNillableCreatedAt(t *time.Time) *RecoveryCodeUpdateOne {
+	if t != nil {
+		rcuo.SetCreatedAt(*t)
+	}
+	return rcuo
+}
+
+// ClearCreatedAt clears the value of the "created_at" field.
+func (rcuo *RecoveryCodeUpdateOne) ClearCreatedAt() *RecoveryCodeUpdateOne {
+	rcuo.mutation.ClearCreatedAt()
+	return rcuo
+}
+
+// Mutation returns the RecoveryCodeMutation object of the builder.
+func (rcuo *RecoveryCodeUpdateOne) Mutation() *RecoveryCodeMutation {
+	return rcuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (rcuo *RecoveryCodeUpdateOne) Select(field string, fields ...string) *RecoveryCodeUpdateOne {
+	rcuo.fields = append([]string{field}, fields...)
+	return rcuo
+}
+
+// Save executes the query and returns the updated RecoveryCode entity.
+func (rcuo *RecoveryCodeUpdateOne) Save(ctx context.Context) (*RecoveryCode, error) {
+	var (
+		err  error
+		node *RecoveryCode
+	)
+	if len(rcuo.hooks) == 0 {
+		node, err = rcuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*RecoveryCodeMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			rcuo.mutation = mutation
+			node, err = rcuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(rcuo.hooks) - 1; i >= 0; i-- {
+			if rcuo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = rcuo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, rcuo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*RecoveryCode)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from RecoveryCodeMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rcuo *RecoveryCodeUpdateOne) SaveX(ctx context.Context) *RecoveryCode {
+	node, err := rcuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (rcuo *RecoveryCodeUpdateOne) Exec(ctx context.Context) error {
+	_, err := rcuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rcuo *RecoveryCodeUpdateOne) ExecX(ctx context.Context) {
+	if err := rcuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (rcuo *RecoveryCodeUpdateOne) sqlSave(ctx context.Context) (_node *RecoveryCode, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   recoverycode.Table,
+			Columns: recoverycode.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: recoverycode.FieldID,
+			},
+		},
+	}
+	id, ok := rcuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "RecoveryCode.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := rcuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, recoverycode.FieldID)
+		for _, f := range fields {
+			if !recoverycode.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != recoverycode.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := rcuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := rcuo.mutation.UserID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: recoverycode.FieldUserID,
+		})
+	}
+	if value, ok := rcuo.mutation.AddedUserID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: recoverycode.FieldUserID,
+		})
+	}
+	if value, ok := rcuo.mutation.CodeHash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: recoverycode.FieldCodeHash,
+		})
+	}
+	if value, ok := rcuo.mutation.UsedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: recoverycode.FieldUsedAt,
+		})
+	}
+	if rcuo.mutation.UsedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: recoverycode.FieldUsedAt,
+		})
+	}
+	if value, ok := rcuo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: recoverycode.FieldCreatedAt,
+		})
+	}
+	if rcuo.mutation.CreatedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: recoverycode.FieldCreatedAt,
+		})
+	}
+	_node = &RecoveryCode{config: rcuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, rcuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{recoverycode.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
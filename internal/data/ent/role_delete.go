@@ -0,0 +1,115 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"user-service/internal/data/ent/predicate"
+	"user-service/internal/data/ent/role"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// RoleDelete is the builder for deleting a Role entity.
+type RoleDelete struct {
+	config
+	hooks    []Hook
+	mutation *RoleMutation
+}
+
+// Where appends a list predicates to the RoleDelete builder.
+func (rd *RoleDelete) Where(ps ...predicate.Role) *RoleDelete {
+	rd.mutation.Where(ps...)
+	return rd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (rd *RoleDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(rd.hooks) == 0 {
+		affected, err = rd.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*RoleMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			rd.mutation = mutation
+			affected, err = rd.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(rd.hooks) - 1; i >= 0; i-- {
+			if rd.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = rd.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, rd.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rd *RoleDelete) ExecX(ctx context.Context) int {
+	n, err := rd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (rd *RoleDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: role.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: role.FieldID,
+			},
+		},
+	}
+	if ps := rd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, rd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	return affected, err
+}
+
+// RoleDeleteOne is the builder for deleting a single Role entity.
+type RoleDeleteOne struct {
+	rd *RoleDelete
+}
+
+// Exec executes the deletion query.
+func (rdo *RoleDeleteOne) Exec(ctx context.Context) error {
+	n, err := rdo.rd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{role.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rdo *RoleDeleteOne) ExecX(ctx context.Context) {
+	rdo.rd.ExecX(ctx)
+}
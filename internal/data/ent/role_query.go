@@ -0,0 +1,734 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"user-service/internal/data/ent/permission"
+	"user-service/internal/data/ent/predicate"
+	"user-service/internal/data/ent/role"
+	"user-service/internal/data/ent/user"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// RoleQuery is the builder for querying Role entities.
+type RoleQuery struct {
+	config
+	limit      *int
+	offset     *int
+	unique     *bool
+	order      []OrderFunc
+	fields     []string
+	predicates []predicate.Role
+	// eager-loading edges.
+	withUsers       *UserQuery
+	withPermissions *PermissionQuery
+	modifiers       []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the RoleQuery builder.
+func (rq *RoleQuery) Where(ps ...predicate.Role) *RoleQuery {
+	rq.predicates = append(rq.predicates, ps...)
+	return rq
+}
+
+// Limit adds a limit step to the query.
+func (rq *RoleQuery) Limit(limit int) *RoleQuery {
+	rq.limit = &limit
+	return rq
+}
+
+// Offset adds an offset step to the query.
+func (rq *RoleQuery) Offset(offset int) *RoleQuery {
+	rq.offset = &offset
+	return rq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (rq *RoleQuery) Unique(unique bool) *RoleQuery {
+	rq.unique = &unique
+	return rq
+}
+
+// Order adds an order step to the query.
+func (rq *RoleQuery) Order(o ...OrderFunc) *RoleQuery {
+	rq.order = append(rq.order, o...)
+	return rq
+}
+
+// QueryUsers chains the current query on the "users" edge.
+func (rq *RoleQuery) QueryUsers() *UserQuery {
+	query := &UserQuery{config: rq.config}
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := rq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := rq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(role.Table, role.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, true, role.UsersTable, role.UsersPrimaryKey...),
+		)
+		fromU = sqlgraph.SetNeighbors(rq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryPermissions chains the current query on the "permissions" edge.
+func (rq *RoleQuery) QueryPermissions() *PermissionQuery {
+	query := &PermissionQuery{config: rq.config}
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := rq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := rq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(role.Table, role.FieldID, selector),
+			sqlgraph.To(permission.Table, permission.FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, role.PermissionsTable, role.PermissionsPrimaryKey...),
+		)
+		fromU = sqlgraph.SetNeighbors(rq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first Role entity from the query.
+// Returns a *NotFoundError when no Role was found.
+func (rq *RoleQuery) First(ctx context.Context) (*Role, error) {
+	nodes, err := rq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{role.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (rq *RoleQuery) FirstX(ctx context.Context) *Role {
+	node, err := rq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first Role ID from the query.
+// Returns a *NotFoundError when no Role ID was found.
+func (rq *RoleQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = rq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{role.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (rq *RoleQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := rq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single Role entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one Role entity is found.
+// Returns a *NotFoundError when no Role entities are found.
+func (rq *RoleQuery) Only(ctx context.Context) (*Role, error) {
+	nodes, err := rq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{role.Label}
+	default:
+		return nil, &NotSingularError{role.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (rq *RoleQuery) OnlyX(ctx context.Context) *Role {
+	node, err := rq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only Role ID in the query.
+// Returns a *NotSingularError when more than one Role ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (rq *RoleQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = rq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{role.Label}
+	default:
+		err = &NotSingularError{role.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (rq *RoleQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := rq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of Roles.
+func (rq *RoleQuery) All(ctx context.Context) ([]*Role, error) {
+	if err := rq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return rq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (rq *RoleQuery) AllX(ctx context.Context) []*Role {
+	nodes, err := rq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of Role IDs.
+func (rq *RoleQuery) IDs(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	if err := rq.Select(role.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (rq *RoleQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := rq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (rq *RoleQuery) Count(ctx context.Context) (int, error) {
+	if err := rq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return rq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (rq *RoleQuery) CountX(ctx context.Context) int {
+	count, err := rq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (rq *RoleQuery) Exist(ctx context.Context) (bool, error) {
+	if err := rq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return rq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (rq *RoleQuery) ExistX(ctx context.Context) bool {
+	exist, err := rq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the RoleQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (rq *RoleQuery) Clone() *RoleQuery {
+	if rq == nil {
+		return nil
+	}
+	return &RoleQuery{
+		config:          rq.config,
+		limit:           rq.limit,
+		offset:          rq.offset,
+		order:           append([]OrderFunc{}, rq.order...),
+		predicates:      append([]predicate.Role{}, rq.predicates...),
+		withUsers:       rq.withUsers.Clone(),
+		withPermissions: rq.withPermissions.Clone(),
+		// clone intermediate query.
+		sql:    rq.sql.Clone(),
+		path:   rq.path,
+		unique: rq.unique,
+	}
+}
+
+// WithUsers tells the query-builder to eager-load the nodes that are connected to
+// the "users" edge. The optional arguments are used to configure the query builder of the edge.
+func (rq *RoleQuery) WithUsers(opts ...func(*UserQuery)) *RoleQuery {
+	query := &UserQuery{config: rq.config}
+	for _, opt := range opts {
+		opt(query)
+	}
+	rq.withUsers = query
+	return rq
+}
+
+// WithPermissions tells the query-builder to eager-load the nodes that are connected to
+// the "permissions" edge. The optional arguments are used to configure the query builder of the edge.
+func (rq *RoleQuery) WithPermissions(opts ...func(*PermissionQuery)) *RoleQuery {
+	query := &PermissionQuery{config: rq.config}
+	for _, opt := range opts {
+		opt(query)
+	}
+	rq.withPermissions = query
+	return rq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.Role.Query().
+//		GroupBy(role.FieldName).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (rq *RoleQuery) GroupBy(field string, fields ...string) *RoleGroupBy {
+	grbuild := &RoleGroupBy{config: rq.config}
+	grbuild.fields = append([]string{field}, fields...)
+	grbuild.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := rq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return rq.sqlQuery(ctx), nil
+	}
+	grbuild.label = role.Label
+	grbuild.flds, grbuild.scan = &grbuild.fields, grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//	}
+//
+//	client.Role.Query().
+//		Select(role.FieldName).
+//		Scan(ctx, &v)
+func (rq *RoleQuery) Select(fields ...string) *RoleSelect {
+	rq.fields = append(rq.fields, fields...)
+	selbuild := &RoleSelect{RoleQuery: rq}
+	selbuild.label = role.Label
+	selbuild.flds, selbuild.scan = &rq.fields, selbuild.Scan
+	return selbuild
+}
+
+func (rq *RoleQuery) prepareQuery(ctx context.Context) error {
+	for _, f := range rq.fields {
+		if !role.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if rq.path != nil {
+		prev, err := rq.path(ctx)
+		if err != nil {
+			return err
+		}
+		rq.sql = prev
+	}
+	return nil
+}
+
+func (rq *RoleQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Role, error) {
+	var (
+		nodes       = []*Role{}
+		_spec       = rq.querySpec()
+		loadedTypes = [2]bool{
+			rq.withUsers != nil,
+			rq.withPermissions != nil,
+		}
+	)
+	_spec.ScanValues = func(columns []string) ([]interface{}, error) {
+		return (*Role).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []interface{}) error {
+		node := &Role{config: rq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	if len(rq.modifiers) > 0 {
+		_spec.Modifiers = rq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, rq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+
+	if query := rq.withUsers; query != nil {
+		edgeids := make([]driver.Value, len(nodes))
+		byid := make(map[int64]*Role)
+		nids := make(map[int64]map[*Role]struct{})
+		for i, node := range nodes {
+			edgeids[i] = node.ID
+			byid[node.ID] = node
+			node.Edges.Users = []*User{}
+		}
+		query.Where(func(s *sql.Selector) {
+			joinT := sql.Table(role.UsersTable)
+			s.Join(joinT).On(s.C(user.FieldID), joinT.C(role.UsersPrimaryKey[0]))
+			s.Where(sql.InValues(joinT.C(role.UsersPrimaryKey[1]), edgeids...))
+			columns := s.SelectedColumns()
+			s.Select(joinT.C(role.UsersPrimaryKey[1]))
+			s.AppendSelect(columns...)
+			s.SetDistinct(false)
+		})
+		neighbors, err := query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+			assign := spec.Assign
+			values := spec.ScanValues
+			spec.ScanValues = func(columns []string) ([]interface{}, error) {
+				values, err := values(columns[1:])
+				if err != nil {
+					return nil, err
+				}
+				return append([]interface{}{new(sql.NullInt64)}, values...), nil
+			}
+			spec.Assign = func(columns []string, values []interface{}) error {
+				outValue := values[0].(*sql.NullInt64).Int64
+				inValue := values[1].(*sql.NullInt64).Int64
+				if nids[inValue] == nil {
+					nids[inValue] = map[*Role]struct{}{byid[outValue]: struct{}{}}
+					return assign(columns[1:], values[1:])
+				}
+				nids[inValue][byid[outValue]] = struct{}{}
+				return nil
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			nodes, ok := nids[n.ID]
+			if !ok {
+				return nil, fmt.Errorf(`unexpected "users" node returned %v`, n.ID)
+			}
+			for kn := range nodes {
+				kn.Edges.Users = append(kn.Edges.Users, n)
+			}
+		}
+	}
+
+	if query := rq.withPermissions; query != nil {
+		edgeids := make([]driver.Value, len(nodes))
+		byid := make(map[int64]*Role)
+		nids := make(map[int64]map[*Role]struct{})
+		for i, node := range nodes {
+			edgeids[i] = node.ID
+			byid[node.ID] = node
+			node.Edges.Permissions = []*Permission{}
+		}
+		query.Where(func(s *sql.Selector) {
+			joinT := sql.Table(role.PermissionsTable)
+			s.Join(joinT).On(s.C(permission.FieldID), joinT.C(role.PermissionsPrimaryKey[1]))
+			s.Where(sql.InValues(joinT.C(role.PermissionsPrimaryKey[0]), edgeids...))
+			columns := s.SelectedColumns()
+			s.Select(joinT.C(role.PermissionsPrimaryKey[0]))
+			s.AppendSelect(columns...)
+			s.SetDistinct(false)
+		})
+		neighbors, err := query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+			assign := spec.Assign
+			values := spec.ScanValues
+			spec.ScanValues = func(columns []string) ([]interface{}, error) {
+				values, err := values(columns[1:])
+				if err != nil {
+					return nil, err
+				}
+				return append([]interface{}{new(sql.NullInt64)}, values...), nil
+			}
+			spec.Assign = func(columns []string, values []interface{}) error {
+				outValue := values[0].(*sql.NullInt64).Int64
+				inValue := values[1].(*sql.NullInt64).Int64
+				if nids[inValue] == nil {
+					nids[inValue] = map[*Role]struct{}{byid[outValue]: struct{}{}}
+					return assign(columns[1:], values[1:])
+				}
+				nids[inValue][byid[outValue]] = struct{}{}
+				return nil
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			nodes, ok := nids[n.ID]
+			if !ok {
+				return nil, fmt.Errorf(`unexpected "permissions" node returned %v`, n.ID)
+			}
+			for kn := range nodes {
+				kn.Edges.Permissions = append(kn.Edges.Permissions, n)
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+func (rq *RoleQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := rq.querySpec()
+	if len(rq.modifiers) > 0 {
+		_spec.Modifiers = rq.modifiers
+	}
+	_spec.Node.Columns = rq.fields
+	if len(rq.fields) > 0 {
+		_spec.Unique = rq.unique != nil && *rq.unique
+	}
+	return sqlgraph.CountNodes(ctx, rq.driver, _spec)
+}
+
+func (rq *RoleQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := rq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (rq *RoleQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   role.Table,
+			Columns: role.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: role.FieldID,
+			},
+		},
+		From:   rq.sql,
+		Unique: true,
+	}
+	if unique := rq.unique; unique != nil {
+		_spec.Unique = *unique
+	}
+	if fields := rq.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, role.FieldID)
+		for i := range fields {
+			if fields[i] != role.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := rq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := rq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := rq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := rq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (rq *RoleQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(rq.driver.Dialect())
+	t1 := builder.Table(role.Table)
+	columns := rq.fields
+	if len(columns) == 0 {
+		columns = role.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if rq.sql != nil {
+		selector = rq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if rq.unique != nil && *rq.unique {
+		selector.Distinct()
+	}
+	for _, m := range rq.modifiers {
+		m(selector)
+	}
+	for _, p := range rq.predicates {
+		p(selector)
+	}
+	for _, p := range rq.order {
+		p(selector)
+	}
+	if offset := rq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := rq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (rq *RoleQuery) Modify(modifiers ...func(s *sql.Selector)) *RoleSelect {
+	rq.modifiers = append(rq.modifiers, modifiers...)
+	return rq.Select()
+}
+
+// RoleGroupBy is the group-by builder for Role entities.
+type RoleGroupBy struct {
+	config
+	selector
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (rgb *RoleGroupBy) Aggregate(fns ...AggregateFunc) *RoleGroupBy {
+	rgb.fns = append(rgb.fns, fns...)
+	return rgb
+}
+
+// Scan applies the group-by query and scans the result into the given value.
+func (rgb *RoleGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := rgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	rgb.sql = query
+	return rgb.sqlScan(ctx, v)
+}
+
+func (rgb *RoleGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range rgb.fields {
+		if !role.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := rgb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := rgb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (rgb *RoleGroupBy) sqlQuery() *sql.Selector {
+	selector := rgb.sql.Select()
+	aggregation := make([]string, 0, len(rgb.fns))
+	for _, fn := range rgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	// If no columns were selected in a custom aggregation function, the default
+	// selection is the fields used for "group-by", and the aggregation functions.
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(rgb.fields)+len(rgb.fns))
+		for _, f := range rgb.fields {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	return selector.GroupBy(selector.Columns(rgb.fields...)...)
+}
+
+// RoleSelect is the builder for selecting fields of Role entities.
+type RoleSelect struct {
+	*RoleQuery
+	selector
+	// intermediate query (i.e. traversal path).
+	sql *sql.Selector
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (rs *RoleSelect) Scan(ctx context.Context, v interface{}) error {
+	if err := rs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	rs.sql = rs.RoleQuery.sqlQuery(ctx)
+	return rs.sqlScan(ctx, v)
+}
+
+func (rs *RoleSelect) sqlScan(ctx context.Context, v interface{}) error {
+	rows := &sql.Rows{}
+	query, args := rs.sql.Query()
+	if err := rs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (rs *RoleSelect) Modify(modifiers ...func(s *sql.Selector)) *RoleSelect {
+	rs.modifiers = append(rs.modifiers, modifiers...)
+	return rs
+}
@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Permission holds the schema definition for the Permission entity.
+type Permission struct {
+	ent.Schema
+}
+
+func (Permission) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "permission"},
+	}
+}
+
+// Fields of the Permission.
+func (Permission) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id"),
+		field.String("code").
+			Unique(),
+		field.String("description").
+			Optional(),
+	}
+}
+
+// Edges of the Permission.
+func (Permission) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("roles", Role.Type).
+			Ref("permissions"),
+	}
+}
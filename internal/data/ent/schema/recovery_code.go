@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// RecoveryCode holds the schema definition for the RecoveryCode entity.
+type RecoveryCode struct {
+	ent.Schema
+}
+
+func (RecoveryCode) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "user_recovery_code"},
+	}
+}
+
+// Fields of the RecoveryCode.
+func (RecoveryCode) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id"),
+		field.Int64("user_id"),
+		// 恢复码的SHA-256哈希
+		field.String("code_hash").
+			Sensitive(),
+		field.Time("used_at").
+			Optional(),
+		field.Time("created_at").
+			Optional(),
+	}
+}
+
+// Indexes of the RecoveryCode.
+func (RecoveryCode) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "code_hash").
+			Unique(),
+	}
+}
@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Role holds the schema definition for the Role entity.
+type Role struct {
+	ent.Schema
+}
+
+func (Role) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "role"},
+	}
+}
+
+// Fields of the Role.
+func (Role) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id"),
+		field.String("name").
+			Unique(),
+		field.String("description").
+			Optional(),
+		field.Time("created_at").
+			Optional(),
+	}
+}
+
+// Edges of the Role.
+func (Role) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("users", User.Type).
+			Ref("roles"),
+		edge.To("permissions", Permission.Type).
+			StorageKey(edge.Table("role_permission"), edge.Columns("role_id", "permission_id")),
+	}
+}
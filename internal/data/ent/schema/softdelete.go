@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"context"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entql"
+	"user-service/internal/data/ent/privacy"
+	"user-service/internal/data/ent/user"
+)
+
+type skipSoftDeleteKey struct{}
+
+// SkipSoftDelete 返回不过滤已软删除数据的context，仅供恢复及清理使用
+func SkipSoftDelete(parent context.Context) context.Context {
+	return context.WithValue(parent, skipSoftDeleteKey{}, true)
+}
+
+func softDeleteSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipSoftDeleteKey{}).(bool)
+	return skip
+}
+
+// Policy 所有查询及更新默认过滤已软删除的用户，并禁止直接物理删除
+func (User) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			filterSoftDeleted(),
+		},
+		Mutation: privacy.MutationPolicy{
+			denyHardDelete(),
+			filterSoftDeleted(),
+		},
+	}
+}
+
+func filterSoftDeleted() privacy.QueryMutationRule {
+	return privacy.FilterFunc(func(ctx context.Context, f privacy.Filter) error {
+		if softDeleteSkipped(ctx) {
+			return privacy.Skip
+		}
+		f.Where(entql.FieldNil(user.FieldDeletedAt))
+		return privacy.Skip
+	})
+}
+
+func denyHardDelete() privacy.MutationRule {
+	return privacy.MutationRuleFunc(func(ctx context.Context, m ent.Mutation) error {
+		if m.Op().Is(ent.OpDelete|ent.OpDeleteOne) && !softDeleteSkipped(ctx) {
+			return privacy.Denyf("用户只能软删除")
+		}
+		return privacy.Skip
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"user-service/internal/biz"
 )
 
 // User holds the schema definition for the User entity.
@@ -24,7 +26,34 @@ func (User) Fields() []ent.Field {
 		field.Int64("id"),
 		field.String("username").
 			Optional(),
+		// 规范化后的用户名，用于唯一约束，见biz.NormalizeUsername
+		field.String("username_key").
+			Optional().
+			Unique(),
 		field.String("password").
+			Optional().
+			Sensitive(),
+		// 规范化后的邮箱，见biz.NormalizeEmail
+		field.String("email").
+			Optional().
+			Unique(),
+		field.Time("email_verified_at").
+			Optional(),
+		// 用户资料
+		field.String("nickname").
+			Optional(),
+		field.String("avatar_url").
+			Optional(),
+		field.String("phone").
+			Optional(),
+		field.String("locale").
+			Optional(),
+		field.String("timezone").
+			Optional(),
+		field.Text("bio").
+			Optional(),
+		// 业务方自定义的扩展属性
+		field.JSON("attributes", map[string]interface{}{}).
 			Optional(),
 		field.Time("created_at").
 			Optional(),
@@ -34,10 +63,27 @@ func (User) Fields() []ent.Field {
 			Optional(),
 		//Default(time.Now().Local).
 		//SchemaType(map[string]string{dialect.MySQL: "datetime"}),
+		field.Enum("status").
+			GoType(biz.UserStatus("")).
+			Default(string(biz.UserStatusActive)),
+		field.String("status_reason").
+			Optional(),
+		field.Int64("status_changed_by").
+			Optional(),
+		field.Time("status_changed_at").
+			Optional(),
+		field.Time("deleted_at").
+			Optional(),
+		// 乐观锁版本号，每次修改自增
+		field.Int64("version").
+			Default(1),
 	}
 }
 
 // Edges of the User.
 func (User) Edges() []ent.Edge {
-	return nil
+	return []ent.Edge{
+		edge.To("roles", Role.Type).
+			StorageKey(edge.Table("user_role"), edge.Columns("user_id", "role_id")),
+	}
 }
@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// UserTotp holds the schema definition for the UserTotp entity.
+type UserTotp struct {
+	ent.Schema
+}
+
+func (UserTotp) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "user_totp"},
+	}
+}
+
+// Fields of the UserTotp.
+func (UserTotp) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id"),
+		field.Int64("user_id").
+			Unique(),
+		// 加密后的TOTP密钥
+		field.String("secret").
+			Sensitive(),
+		// 为空表示已绑定但尚未确认
+		field.Time("confirmed_at").
+			Optional(),
+		// 最近一次使用的时间步，防止验证码被重放
+		field.Int64("last_used_step").
+			Optional(),
+		field.Time("created_at").
+			Optional(),
+	}
+}
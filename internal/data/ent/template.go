@@ -4,15 +4,116 @@ package ent
 
 import "user-service/internal/biz"
 
+func (pu *PermissionUpdate) SetPermission(input *biz.Permission) *PermissionUpdate {
+
+	pu.SetCode(input.Code)
+
+	pu.SetNillableDescription(input.Description)
+
+	return pu
+}
+
+func (pc *PermissionCreate) SetPermission(input *biz.Permission) *PermissionCreate {
+
+	pc.SetCode(input.Code)
+
+	pc.SetNillableDescription(input.Description)
+
+	return pc
+}
+
+func (rcu *RecoveryCodeUpdate) SetRecoveryCode(input *biz.RecoveryCode) *RecoveryCodeUpdate {
+
+	rcu.SetUserID(input.UserId)
+
+	rcu.SetCodeHash(input.CodeHash)
+
+	rcu.SetNillableUsedAt(input.UsedAt)
+
+	rcu.SetNillableCreatedAt(input.CreatedAt)
+
+	return rcu
+}
+
+func (rcc *RecoveryCodeCreate) SetRecoveryCode(input *biz.RecoveryCode) *RecoveryCodeCreate {
+
+	rcc.SetUserID(input.UserId)
+
+	rcc.SetCodeHash(input.CodeHash)
+
+	rcc.SetNillableUsedAt(input.UsedAt)
+
+	rcc.SetNillableCreatedAt(input.CreatedAt)
+
+	return rcc
+}
+
+func (ru *RoleUpdate) SetRole(input *biz.Role) *RoleUpdate {
+
+	ru.SetName(input.Name)
+
+	ru.SetNillableDescription(input.Description)
+
+	ru.SetNillableCreatedAt(input.CreatedAt)
+
+	return ru
+}
+
+func (rc *RoleCreate) SetRole(input *biz.Role) *RoleCreate {
+
+	rc.SetName(input.Name)
+
+	rc.SetNillableDescription(input.Description)
+
+	rc.SetNillableCreatedAt(input.CreatedAt)
+
+	return rc
+}
+
 func (uu *UserUpdate) SetUser(input *biz.User) *UserUpdate {
 
 	uu.SetNillableUsername(input.Username)
 
+	uu.SetNillableUsernameKey(input.UsernameKey)
+
 	uu.SetNillablePassword(input.Password)
 
+	uu.SetNillableEmail(input.Email)
+
+	uu.SetNillableEmailVerifiedAt(input.EmailVerifiedAt)
+
+	uu.SetNillableNickname(input.Nickname)
+
+	uu.SetNillableAvatarURL(input.AvatarUrl)
+
+	uu.SetNillablePhone(input.Phone)
+
+	uu.SetNillableLocale(input.Locale)
+
+	uu.SetNillableTimezone(input.Timezone)
+
+	uu.SetNillableBio(input.Bio)
+
+	if input.Attributes != nil {
+		uu.SetAttributes(input.Attributes)
+	}
+
 	uu.SetNillableCreatedAt(input.CreatedAt)
 
 	uu.SetNillableUpdatedAt(input.UpdatedAt)
+
+	uu.SetNillableStatus(input.Status)
+
+	uu.SetNillableStatusReason(input.StatusReason)
+
+	uu.SetNillableStatusChangedBy(input.StatusChangedBy)
+
+	uu.SetNillableStatusChangedAt(input.StatusChangedAt)
+
+	uu.SetNillableDeletedAt(input.DeletedAt)
+
+	uu.SetNillableVersion(input.Version)
+
 	return uu
 }
 
@@ -20,10 +121,75 @@ func (uc *UserCreate) SetUser(input *biz.User) *UserCreate {
 
 	uc.SetNillableUsername(input.Username)
 
+	uc.SetNillableUsernameKey(input.UsernameKey)
+
 	uc.SetNillablePassword(input.Password)
 
+	uc.SetNillableEmail(input.Email)
+
+	uc.SetNillableEmailVerifiedAt(input.EmailVerifiedAt)
+
+	uc.SetNillableNickname(input.Nickname)
+
+	uc.SetNillableAvatarURL(input.AvatarUrl)
+
+	uc.SetNillablePhone(input.Phone)
+
+	uc.SetNillableLocale(input.Locale)
+
+	uc.SetNillableTimezone(input.Timezone)
+
+	uc.SetNillableBio(input.Bio)
+
+	if input.Attributes != nil {
+		uc.SetAttributes(input.Attributes)
+	}
+
 	uc.SetNillableCreatedAt(input.CreatedAt)
 
 	uc.SetNillableUpdatedAt(input.UpdatedAt)
+
+	uc.SetNillableStatus(input.Status)
+
+	uc.SetNillableStatusReason(input.StatusReason)
+
+	uc.SetNillableStatusChangedBy(input.StatusChangedBy)
+
+	uc.SetNillableStatusChangedAt(input.StatusChangedAt)
+
+	uc.SetNillableDeletedAt(input.DeletedAt)
+
+	uc.SetNillableVersion(input.Version)
+
 	return uc
 }
+
+func (utu *UserTotpUpdate) SetUserTotp(input *biz.UserTotp) *UserTotpUpdate {
+
+	utu.SetUserID(input.UserId)
+
+	utu.SetSecret(input.Secret)
+
+	utu.SetNillableConfirmedAt(input.ConfirmedAt)
+
+	utu.SetNillableLastUsedStep(input.LastUsedStep)
+
+	utu.SetNillableCreatedAt(input.CreatedAt)
+
+	return utu
+}
+
+func (utc *UserTotpCreate) SetUserTotp(input *biz.UserTotp) *UserTotpCreate {
+
+	utc.SetUserID(input.UserId)
+
+	utc.SetSecret(input.Secret)
+
+	utc.SetNillableConfirmedAt(input.ConfirmedAt)
+
+	utc.SetNillableLastUsedStep(input.LastUsedStep)
+
+	utc.SetNillableCreatedAt(input.CreatedAt)
+
+	return utc
+}
@@ -0,0 +1,145 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"user-service/internal/data/ent/usertotp"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// UserTotp is the model entity for the UserTotp schema.
+type UserTotp struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int64 `json:"user_id,omitempty"`
+	// Secret holds the value of the "secret" field.
+	Secret string `json:"-"`
+	// ConfirmedAt holds the value of the "confirmed_at" field.
+	ConfirmedAt time.Time `json:"confirmed_at,omitempty"`
+	// LastUsedStep holds the value of the "last_used_step" field.
+	LastUsedStep int64 `json:"last_used_step,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*UserTotp) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case usertotp.FieldID, usertotp.FieldUserID, usertotp.FieldLastUsedStep:
+			values[i] = new(sql.NullInt64)
+		case usertotp.FieldSecret:
+			values[i] = new(sql.NullString)
+		case usertotp.FieldConfirmedAt, usertotp.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type UserTotp", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the UserTotp fields.
+func (ut *UserTotp) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case usertotp.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			ut.ID = int64(value.Int64)
+		case usertotp.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				ut.UserID = value.Int64
+			}
+		case usertotp.FieldSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field secret", values[i])
+			} else if value.Valid {
+				ut.Secret = value.String
+			}
+		case usertotp.FieldConfirmedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field confirmed_at", values[i])
+			} else if value.Valid {
+				ut.ConfirmedAt = value.Time
+			}
+		case usertotp.FieldLastUsedStep:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field last_used_step", values[i])
+			} else if value.Valid {
+				ut.LastUsedStep = value.Int64
+			}
+		case usertotp.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				ut.CreatedAt = value.Time
+			}
+		}
+	}
+	return nil
+}
+
+// Update returns a builder for updating this UserTotp.
+// Note that you need to call UserTotp.Unwrap() before calling this method if this UserTotp
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ut *UserTotp) Update() *UserTotpUpdateOne {
+	return (&UserTotpClient{config: ut.config}).UpdateOne(ut)
+}
+
+// Unwrap unwraps the UserTotp entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ut *UserTotp) Unwrap() *UserTotp {
+	_tx, ok := ut.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: UserTotp is not a transactional entity")
+	}
+	ut.config.driver = _tx.drv
+	return ut
+}
+
+// String implements the fmt.Stringer.
+func (ut *UserTotp) String() string {
+	var builder strings.Builder
+	builder.WriteString("UserTotp(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ut.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", ut.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("secret=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("confirmed_at=")
+	builder.WriteString(ut.ConfirmedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("last_used_step=")
+	builder.WriteString(fmt.Sprintf("%v", ut.LastUsedStep))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(ut.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// UserTotps is a parsable slice of UserTotp.
+type UserTotps []*UserTotp
+
+func (ut UserTotps) config(cfg config) {
+	for _i := range ut {
+		ut[_i].config = cfg
+	}
+}
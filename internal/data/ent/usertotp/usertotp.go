@@ -0,0 +1,42 @@
+// Code generated by ent, DO NOT EDIT.
+
+package usertotp
+
+const (
+	// Label holds the string label denoting the usertotp type in the database.
+	Label = "user_totp"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldSecret holds the string denoting the secret field in the database.
+	FieldSecret = "secret"
+	// FieldConfirmedAt holds the string denoting the confirmed_at field in the database.
+	FieldConfirmedAt = "confirmed_at"
+	// FieldLastUsedStep holds the string denoting the last_used_step field in the database.
+	FieldLastUsedStep = "last_used_step"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the usertotp in the database.
+	Table = "user_totp"
+)
+
+// Columns holds all SQL columns for usertotp fields.
+var Columns = []string{
+	FieldID,
+	FieldUserID,
+	FieldSecret,
+	FieldConfirmedAt,
+	FieldLastUsedStep,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
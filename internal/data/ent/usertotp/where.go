@@ -0,0 +1,605 @@
+// Code generated by ent, DO NOT EDIT.
+
+package usertotp
+
+import (
+	"time"
+	"user-service/internal/data/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldUserID), v))
+	})
+}
+
+// Secret applies equality check predicate on the "secret" field. It's identical to SecretEQ.
+func Secret(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldSecret), v))
+	})
+}
+
+// ConfirmedAt applies equality check predicate on the "confirmed_at" field. It's identical to ConfirmedAtEQ.
+func ConfirmedAt(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldConfirmedAt), v))
+	})
+}
+
+// LastUsedStep applies equality check predicate on the "last_used_step" field. It's identical to LastUsedStepEQ.
+func LastUsedStep(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastUsedStep), v))
+	})
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...int64) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldUserID), v...))
+	})
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...int64) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldUserID), v...))
+	})
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldUserID), v))
+	})
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldUserID), v))
+	})
+}
+
+// SecretEQ applies the EQ predicate on the "secret" field.
+func SecretEQ(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldSecret), v))
+	})
+}
+
+// SecretNEQ applies the NEQ predicate on the "secret" field.
+func SecretNEQ(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldSecret), v))
+	})
+}
+
+// SecretIn applies the In predicate on the "secret" field.
+func SecretIn(vs ...string) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldSecret), v...))
+	})
+}
+
+// SecretNotIn applies the NotIn predicate on the "secret" field.
+func SecretNotIn(vs ...string) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldSecret), v...))
+	})
+}
+
+// SecretGT applies the GT predicate on the "secret" field.
+func SecretGT(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldSecret), v))
+	})
+}
+
+// SecretGTE applies the GTE predicate on the "secret" field.
+func SecretGTE(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldSecret), v))
+	})
+}
+
+// SecretLT applies the LT predicate on the "secret" field.
+func SecretLT(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldSecret), v))
+	})
+}
+
+// SecretLTE applies the LTE predicate on the "secret" field.
+func SecretLTE(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldSecret), v))
+	})
+}
+
+// SecretContains applies the Contains predicate on the "secret" field.
+func SecretContains(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldSecret), v))
+	})
+}
+
+// SecretHasPrefix applies the HasPrefix predicate on the "secret" field.
+func SecretHasPrefix(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldSecret), v))
+	})
+}
+
+// SecretHasSuffix applies the HasSuffix predicate on the "secret" field.
+func SecretHasSuffix(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldSecret), v))
+	})
+}
+
+// SecretEqualFold applies the EqualFold predicate on the "secret" field.
+func SecretEqualFold(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldSecret), v))
+	})
+}
+
+// SecretContainsFold applies the ContainsFold predicate on the "secret" field.
+func SecretContainsFold(v string) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldSecret), v))
+	})
+}
+
+// ConfirmedAtEQ applies the EQ predicate on the "confirmed_at" field.
+func ConfirmedAtEQ(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldConfirmedAt), v))
+	})
+}
+
+// ConfirmedAtNEQ applies the NEQ predicate on the "confirmed_at" field.
+func ConfirmedAtNEQ(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldConfirmedAt), v))
+	})
+}
+
+// ConfirmedAtIn applies the In predicate on the "confirmed_at" field.
+func ConfirmedAtIn(vs ...time.Time) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldConfirmedAt), v...))
+	})
+}
+
+// ConfirmedAtNotIn applies the NotIn predicate on the "confirmed_at" field.
+func ConfirmedAtNotIn(vs ...time.Time) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldConfirmedAt), v...))
+	})
+}
+
+// ConfirmedAtGT applies the GT predicate on the "confirmed_at" field.
+func ConfirmedAtGT(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldConfirmedAt), v))
+	})
+}
+
+// ConfirmedAtGTE applies the GTE predicate on the "confirmed_at" field.
+func ConfirmedAtGTE(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldConfirmedAt), v))
+	})
+}
+
+// ConfirmedAtLT applies the LT predicate on the "confirmed_at" field.
+func ConfirmedAtLT(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldConfirmedAt), v))
+	})
+}
+
+// ConfirmedAtLTE applies the LTE predicate on the "confirmed_at" field.
+func ConfirmedAtLTE(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldConfirmedAt), v))
+	})
+}
+
+// ConfirmedAtIsNil applies the IsNil predicate on the "confirmed_at" field.
+func ConfirmedAtIsNil() predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldConfirmedAt)))
+	})
+}
+
+// ConfirmedAtNotNil applies the NotNil predicate on the "confirmed_at" field.
+func ConfirmedAtNotNil() predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldConfirmedAt)))
+	})
+}
+
+// LastUsedStepEQ applies the EQ predicate on the "last_used_step" field.
+func LastUsedStepEQ(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastUsedStep), v))
+	})
+}
+
+// LastUsedStepNEQ applies the NEQ predicate on the "last_used_step" field.
+func LastUsedStepNEQ(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldLastUsedStep), v))
+	})
+}
+
+// LastUsedStepIn applies the In predicate on the "last_used_step" field.
+func LastUsedStepIn(vs ...int64) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldLastUsedStep), v...))
+	})
+}
+
+// LastUsedStepNotIn applies the NotIn predicate on the "last_used_step" field.
+func LastUsedStepNotIn(vs ...int64) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldLastUsedStep), v...))
+	})
+}
+
+// LastUsedStepGT applies the GT predicate on the "last_used_step" field.
+func LastUsedStepGT(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldLastUsedStep), v))
+	})
+}
+
+// LastUsedStepGTE applies the GTE predicate on the "last_used_step" field.
+func LastUsedStepGTE(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldLastUsedStep), v))
+	})
+}
+
+// LastUsedStepLT applies the LT predicate on the "last_used_step" field.
+func LastUsedStepLT(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldLastUsedStep), v))
+	})
+}
+
+// LastUsedStepLTE applies the LTE predicate on the "last_used_step" field.
+func LastUsedStepLTE(v int64) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldLastUsedStep), v))
+	})
+}
+
+// LastUsedStepIsNil applies the IsNil predicate on the "last_used_step" field.
+func LastUsedStepIsNil() predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldLastUsedStep)))
+	})
+}
+
+// LastUsedStepNotNil applies the NotNil predicate on the "last_used_step" field.
+func LastUsedStepNotNil() predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldLastUsedStep)))
+	})
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.UserTotp {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UserTotp(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtIsNil applies the IsNil predicate on the "created_at" field.
+func CreatedAtIsNil() predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldCreatedAt)))
+	})
+}
+
+// CreatedAtNotNil applies the NotNil predicate on the "created_at" field.
+func CreatedAtNotNil() predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldCreatedAt)))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.UserTotp) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.UserTotp) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.UserTotp) predicate.UserTotp {
+	return predicate.UserTotp(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,322 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"user-service/internal/data/ent/usertotp"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// UserTotpCreate is the builder for creating a UserTotp entity.
+type UserTotpCreate struct {
+	config
+	mutation *UserTotpMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (utc *UserTotpCreate) SetUserID(i int64) *UserTotpCreate {
+	utc.mutation.SetUserID(i)
+	return utc
+}
+
+// SetSecret sets the "secret" field.
+func (utc *UserTotpCreate) SetSecret(s string) *UserTotpCreate {
+	utc.mutation.SetSecret(s)
+	return utc
+}
+
+// SetConfirmedAt sets the "confirmed_at" field.
+func (utc *UserTotpCreate) SetConfirmedAt(t time.Time) *UserTotpCreate {
+	utc.mutation.SetConfirmedAt(t)
+	return utc
+}
+
+// SetNillableConfirmedAt sets the "confirmed_at" field if the given value is not nil.
+func (utc *UserTotpCreate) SetNillableConfirmedAt(t *time.Time) *UserTotpCreate {
+	if t != nil {
+		utc.SetConfirmedAt(*t)
+	}
+	return utc
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (utc *UserTotpCreate) SetLastUsedStep(i int64) *UserTotpCreate {
+	utc.mutation.SetLastUsedStep(i)
+	return utc
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (utc *UserTotpCreate) SetNillableLastUsedStep(i *int64) *UserTotpCreate {
+	if i != nil {
+		utc.SetLastUsedStep(*i)
+	}
+	return utc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (utc *UserTotpCreate) SetCreatedAt(t time.Time) *UserTotpCreate {
+	utc.mutation.SetCreatedAt(t)
+	return utc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (utc *UserTotpCreate) SetNillableCreatedAt(t *time.Time) *UserTotpCreate {
+	if t != nil {
+		utc.SetCreatedAt(*t)
+	}
+	return utc
+}
+
+// SetID sets the "id" field.
+func (utc *UserTotpCreate) SetID(i int64) *UserTotpCreate {
+	utc.mutation.SetID(i)
+	return utc
+}
+
+// Mutation returns the UserTotpMutation object of the builder.
+func (utc *UserTotpCreate) Mutation() *UserTotpMutation {
+	return utc.mutation
+}
+
+// Save creates the UserTotp in the database.
+func (utc *UserTotpCreate) Save(ctx context.Context) (*UserTotp, error) {
+	var (
+		err  error
+		node *UserTotp
+	)
+	if len(utc.hooks) == 0 {
+		if err = utc.check(); err != nil {
+			return nil, err
+		}
+		node, err = utc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*UserTotpMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = utc.check(); err != nil {
+				return nil, err
+			}
+			utc.mutation = mutation
+			if node, err = utc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(utc.hooks) - 1; i >= 0; i-- {
+			if utc.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = utc.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, utc.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*UserTotp)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from UserTotpMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (utc *UserTotpCreate) SaveX(ctx context.Context) *UserTotp {
+	v, err := utc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (utc *UserTotpCreate) Exec(ctx context.Context) error {
+	_, err := utc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (utc *UserTotpCreate) ExecX(ctx context.Context) {
+	if err := utc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (utc *UserTotpCreate) check() error {
+	if _, ok := utc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "UserTotp.user_id"`)}
+	}
+	if _, ok := utc.mutation.Secret(); !ok {
+		return &ValidationError{Name: "secret", err: errors.New(`ent: missing required field "UserTotp.secret"`)}
+	}
+	return nil
+}
+
+func (utc *UserTotpCreate) sqlSave(ctx context.Context) (*UserTotp, error) {
+	_node, _spec := utc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, utc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	return _node, nil
+}
+
+func (utc *UserTotpCreate) createSpec() (*UserTotp, *sqlgraph.CreateSpec) {
+	var (
+		_node = &UserTotp{config: utc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: usertotp.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: usertotp.FieldID,
+			},
+		}
+	)
+	if id, ok := utc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := utc.mutation.UserID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldUserID,
+		})
+		_node.UserID = value
+	}
+	if value, ok := utc.mutation.Secret(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: usertotp.FieldSecret,
+		})
+		_node.Secret = value
+	}
+	if value, ok := utc.mutation.ConfirmedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: usertotp.FieldConfirmedAt,
+		})
+		_node.ConfirmedAt = value
+	}
+	if value, ok := utc.mutation.LastUsedStep(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldLastUsedStep,
+		})
+		_node.LastUsedStep = value
+	}
+	if value, ok := utc.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: usertotp.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// UserTotpCreateBulk is the builder for creating many UserTotp entities in bulk.
+type UserTotpCreateBulk struct {
+	config
+	builders []*UserTotpCreate
+}
+
+// Save creates the UserTotp entities in the database.
+func (utcb *UserTotpCreateBulk) Save(ctx context.Context) ([]*UserTotp, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(utcb.builders))
+	nodes := make([]*UserTotp, len(utcb.builders))
+	mutators := make([]Mutator, len(utcb.builders))
+	for i := range utcb.builders {
+		func(i int, root context.Context) {
+			builder := utcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*UserTotpMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, utcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, utcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, utcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (utcb *UserTotpCreateBulk) SaveX(ctx context.Context) []*UserTotp {
+	v, err := utcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (utcb *UserTotpCreateBulk) Exec(ctx context.Context) error {
+	_, err := utcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (utcb *UserTotpCreateBulk) ExecX(ctx context.Context) {
+	if err := utcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,115 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"user-service/internal/data/ent/predicate"
+	"user-service/internal/data/ent/usertotp"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// UserTotpDelete is the builder for deleting a UserTotp entity.
+type UserTotpDelete struct {
+	config
+	hooks    []Hook
+	mutation *UserTotpMutation
+}
+
+// Where appends a list predicates to the UserTotpDelete builder.
+func (utd *UserTotpDelete) Where(ps ...predicate.UserTotp) *UserTotpDelete {
+	utd.mutation.Where(ps...)
+	return utd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (utd *UserTotpDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(utd.hooks) == 0 {
+		affected, err = utd.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*UserTotpMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			utd.mutation = mutation
+			affected, err = utd.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(utd.hooks) - 1; i >= 0; i-- {
+			if utd.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = utd.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, utd.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (utd *UserTotpDelete) ExecX(ctx context.Context) int {
+	n, err := utd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (utd *UserTotpDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: usertotp.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: usertotp.FieldID,
+			},
+		},
+	}
+	if ps := utd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, utd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	return affected, err
+}
+
+// UserTotpDeleteOne is the builder for deleting a single UserTotp entity.
+type UserTotpDeleteOne struct {
+	utd *UserTotpDelete
+}
+
+// Exec executes the deletion query.
+func (utdo *UserTotpDeleteOne) Exec(ctx context.Context) error {
+	n, err := utdo.utd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{usertotp.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (utdo *UserTotpDeleteOne) ExecX(ctx context.Context) {
+	utdo.utd.ExecX(ctx)
+}
@@ -0,0 +1,548 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"user-service/internal/data/ent/predicate"
+	"user-service/internal/data/ent/usertotp"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// UserTotpQuery is the builder for querying UserTotp entities.
+type UserTotpQuery struct {
+	config
+	limit      *int
+	offset     *int
+	unique     *bool
+	order      []OrderFunc
+	fields     []string
+	predicates []predicate.UserTotp
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the UserTotpQuery builder.
+func (utq *UserTotpQuery) Where(ps ...predicate.UserTotp) *UserTotpQuery {
+	utq.predicates = append(utq.predicates, ps...)
+	return utq
+}
+
+// Limit adds a limit step to the query.
+func (utq *UserTotpQuery) Limit(limit int) *UserTotpQuery {
+	utq.limit = &limit
+	return utq
+}
+
+// Offset adds an offset step to the query.
+func (utq *UserTotpQuery) Offset(offset int) *UserTotpQuery {
+	utq.offset = &offset
+	return utq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (utq *UserTotpQuery) Unique(unique bool) *UserTotpQuery {
+	utq.unique = &unique
+	return utq
+}
+
+// Order adds an order step to the query.
+func (utq *UserTotpQuery) Order(o ...OrderFunc) *UserTotpQuery {
+	utq.order = append(utq.order, o...)
+	return utq
+}
+
+// First returns the first UserTotp entity from the query.
+// Returns a *NotFoundError when no UserTotp was found.
+func (utq *UserTotpQuery) First(ctx context.Context) (*UserTotp, error) {
+	nodes, err := utq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{usertotp.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (utq *UserTotpQuery) FirstX(ctx context.Context) *UserTotp {
+	node, err := utq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first UserTotp ID from the query.
+// Returns a *NotFoundError when no UserTotp ID was found.
+func (utq *UserTotpQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = utq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{usertotp.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (utq *UserTotpQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := utq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single UserTotp entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one UserTotp entity is found.
+// Returns a *NotFoundError when no UserTotp entities are found.
+func (utq *UserTotpQuery) Only(ctx context.Context) (*UserTotp, error) {
+	nodes, err := utq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{usertotp.Label}
+	default:
+		return nil, &NotSingularError{usertotp.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (utq *UserTotpQuery) OnlyX(ctx context.Context) *UserTotp {
+	node, err := utq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only UserTotp ID in the query.
+// Returns a *NotSingularError when more than one UserTotp ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (utq *UserTotpQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = utq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{usertotp.Label}
+	default:
+		err = &NotSingularError{usertotp.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (utq *UserTotpQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := utq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of UserTotps.
+func (utq *UserTotpQuery) All(ctx context.Context) ([]*UserTotp, error) {
+	if err := utq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return utq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (utq *UserTotpQuery) AllX(ctx context.Context) []*UserTotp {
+	nodes, err := utq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of UserTotp IDs.
+func (utq *UserTotpQuery) IDs(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	if err := utq.Select(usertotp.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (utq *UserTotpQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := utq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (utq *UserTotpQuery) Count(ctx context.Context) (int, error) {
+	if err := utq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return utq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (utq *UserTotpQuery) CountX(ctx context.Context) int {
+	count, err := utq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (utq *UserTotpQuery) Exist(ctx context.Context) (bool, error) {
+	if err := utq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return utq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (utq *UserTotpQuery) ExistX(ctx context.Context) bool {
+	exist, err := utq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the UserTotpQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (utq *UserTotpQuery) Clone() *UserTotpQuery {
+	if utq == nil {
+		return nil
+	}
+	return &UserTotpQuery{
+		config:     utq.config,
+		limit:      utq.limit,
+		offset:     utq.offset,
+		order:      append([]OrderFunc{}, utq.order...),
+		predicates: append([]predicate.UserTotp{}, utq.predicates...),
+		// clone intermediate query.
+		sql:    utq.sql.Clone(),
+		path:   utq.path,
+		unique: utq.unique,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID int64 `json:"user_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.UserTotp.Query().
+//		GroupBy(usertotp.FieldUserID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (utq *UserTotpQuery) GroupBy(field string, fields ...string) *UserTotpGroupBy {
+	grbuild := &UserTotpGroupBy{config: utq.config}
+	grbuild.fields = append([]string{field}, fields...)
+	grbuild.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := utq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return utq.sqlQuery(ctx), nil
+	}
+	grbuild.label = usertotp.Label
+	grbuild.flds, grbuild.scan = &grbuild.fields, grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID int64 `json:"user_id,omitempty"`
+//	}
+//
+//	client.UserTotp.Query().
+//		Select(usertotp.FieldUserID).
+//		Scan(ctx, &v)
+func (utq *UserTotpQuery) Select(fields ...string) *UserTotpSelect {
+	utq.fields = append(utq.fields, fields...)
+	selbuild := &UserTotpSelect{UserTotpQuery: utq}
+	selbuild.label = usertotp.Label
+	selbuild.flds, selbuild.scan = &utq.fields, selbuild.Scan
+	return selbuild
+}
+
+func (utq *UserTotpQuery) prepareQuery(ctx context.Context) error {
+	for _, f := range utq.fields {
+		if !usertotp.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if utq.path != nil {
+		prev, err := utq.path(ctx)
+		if err != nil {
+			return err
+		}
+		utq.sql = prev
+	}
+	return nil
+}
+
+func (utq *UserTotpQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*UserTotp, error) {
+	var (
+		nodes = []*UserTotp{}
+		_spec = utq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]interface{}, error) {
+		return (*UserTotp).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []interface{}) error {
+		node := &UserTotp{config: utq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(utq.modifiers) > 0 {
+		_spec.Modifiers = utq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, utq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (utq *UserTotpQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := utq.querySpec()
+	if len(utq.modifiers) > 0 {
+		_spec.Modifiers = utq.modifiers
+	}
+	_spec.Node.Columns = utq.fields
+	if len(utq.fields) > 0 {
+		_spec.Unique = utq.unique != nil && *utq.unique
+	}
+	return sqlgraph.CountNodes(ctx, utq.driver, _spec)
+}
+
+func (utq *UserTotpQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := utq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (utq *UserTotpQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   usertotp.Table,
+			Columns: usertotp.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: usertotp.FieldID,
+			},
+		},
+		From:   utq.sql,
+		Unique: true,
+	}
+	if unique := utq.unique; unique != nil {
+		_spec.Unique = *unique
+	}
+	if fields := utq.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, usertotp.FieldID)
+		for i := range fields {
+			if fields[i] != usertotp.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := utq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := utq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := utq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := utq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (utq *UserTotpQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(utq.driver.Dialect())
+	t1 := builder.Table(usertotp.Table)
+	columns := utq.fields
+	if len(columns) == 0 {
+		columns = usertotp.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if utq.sql != nil {
+		selector = utq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if utq.unique != nil && *utq.unique {
+		selector.Distinct()
+	}
+	for _, m := range utq.modifiers {
+		m(selector)
+	}
+	for _, p := range utq.predicates {
+		p(selector)
+	}
+	for _, p := range utq.order {
+		p(selector)
+	}
+	if offset := utq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := utq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (utq *UserTotpQuery) Modify(modifiers ...func(s *sql.Selector)) *UserTotpSelect {
+	utq.modifiers = append(utq.modifiers, modifiers...)
+	return utq.Select()
+}
+
+// UserTotpGroupBy is the group-by builder for UserTotp entities.
+type UserTotpGroupBy struct {
+	config
+	selector
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (utgb *UserTotpGroupBy) Aggregate(fns ...AggregateFunc) *UserTotpGroupBy {
+	utgb.fns = append(utgb.fns, fns...)
+	return utgb
+}
+
+// Scan applies the group-by query and scans the result into the given value.
+func (utgb *UserTotpGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := utgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	utgb.sql = query
+	return utgb.sqlScan(ctx, v)
+}
+
+func (utgb *UserTotpGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range utgb.fields {
+		if !usertotp.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := utgb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := utgb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (utgb *UserTotpGroupBy) sqlQuery() *sql.Selector {
+	selector := utgb.sql.Select()
+	aggregation := make([]string, 0, len(utgb.fns))
+	for _, fn := range utgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	// If no columns were selected in a custom aggregation function, the default
+	// selection is the fields used for "group-by", and the aggregation functions.
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(utgb.fields)+len(utgb.fns))
+		for _, f := range utgb.fields {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	return selector.GroupBy(selector.Columns(utgb.fields...)...)
+}
+
+// UserTotpSelect is the builder for selecting fields of UserTotp entities.
+type UserTotpSelect struct {
+	*UserTotpQuery
+	selector
+	// intermediate query (i.e. traversal path).
+	sql *sql.Selector
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (uts *UserTotpSelect) Scan(ctx context.Context, v interface{}) error {
+	if err := uts.prepareQuery(ctx); err != nil {
+		return err
+	}
+	uts.sql = uts.UserTotpQuery.sqlQuery(ctx)
+	return uts.sqlScan(ctx, v)
+}
+
+func (uts *UserTotpSelect) sqlScan(ctx context.Context, v interface{}) error {
+	rows := &sql.Rows{}
+	query, args := uts.sql.Query()
+	if err := uts.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (uts *UserTotpSelect) Modify(modifiers ...func(s *sql.Selector)) *UserTotpSelect {
+	uts.modifiers = append(uts.modifiers, modifiers...)
+	return uts
+}
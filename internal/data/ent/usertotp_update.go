@@ -0,0 +1,552 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"user-service/internal/data/ent/predicate"
+	"user-service/internal/data/ent/usertotp"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// UserTotpUpdate is the builder for updating UserTotp entities.
+type UserTotpUpdate struct {
+	config
+	hooks    []Hook
+	mutation *UserTotpMutation
+}
+
+// Where appends a list predicates to the UserTotpUpdate builder.
+func (utu *UserTotpUpdate) Where(ps ...predicate.UserTotp) *UserTotpUpdate {
+	utu.mutation.Where(ps...)
+	return utu
+}
+
+// SetUserID sets the "user_id" field.
+func (utu *UserTotpUpdate) SetUserID(i int64) *UserTotpUpdate {
+	utu.mutation.ResetUserID()
+	utu.mutation.SetUserID(i)
+	return utu
+}
+
+// AddUserID adds i to the "user_id" field.
+func (utu *UserTotpUpdate) AddUserID(i int64) *UserTotpUpdate {
+	utu.mutation.AddUserID(i)
+	return utu
+}
+
+// SetSecret sets the "secret" field.
+func (utu *UserTotpUpdate) SetSecret(s string) *UserTotpUpdate {
+	utu.mutation.SetSecret(s)
+	return utu
+}
+
+// SetConfirmedAt sets the "confirmed_at" field.
+func (utu *UserTotpUpdate) SetConfirmedAt(t time.Time) *UserTotpUpdate {
+	utu.mutation.SetConfirmedAt(t)
+	return utu
+}
+
+// SetNillableConfirmedAt sets the "confirmed_at" field if the given value is not nil.
+func (utu *UserTotpUpdate) SetNillableConfirmedAt(t *time.Time) *UserTotpUpdate {
+	if t != nil {
+		utu.SetConfirmedAt(*t)
+	}
+	return utu
+}
+
+// ClearConfirmedAt clears the value of the "confirmed_at" field.
+func (utu *UserTotpUpdate) ClearConfirmedAt() *UserTotpUpdate {
+	utu.mutation.ClearConfirmedAt()
+	return utu
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (utu *UserTotpUpdate) SetLastUsedStep(i int64) *UserTotpUpdate {
+	utu.mutation.ResetLastUsedStep()
+	utu.mutation.SetLastUsedStep(i)
+	return utu
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (utu *UserTotpUpdate) SetNillableLastUsedStep(i *int64) *UserTotpUpdate {
+	if i != nil {
+		utu.SetLastUsedStep(*i)
+	}
+	return utu
+}
+
+// AddLastUsedStep adds i to the "last_used_step" field.
+func (utu *UserTotpUpdate) AddLastUsedStep(i int64) *UserTotpUpdate {
+	utu.mutation.AddLastUsedStep(i)
+	return utu
+}
+
+// ClearLastUsedStep clears the value of the "last_used_step" field.
+func (utu *UserTotpUpdate) ClearLastUsedStep() *UserTotpUpdate {
+	utu.mutation.ClearLastUsedStep()
+	return utu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (utu *UserTotpUpdate) SetCreatedAt(t time.Time) *UserTotpUpdate {
+	utu.mutation.SetCreatedAt(t)
+	return utu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (utu *UserTotpUpdate) SetNillableCreatedAt(t *time.Time) *UserTotpUpdate {
+	if t != nil {
+		utu.SetCreatedAt(*t)
+	}
+	return utu
+}
+
+// ClearCreatedAt clears the value of the "created_at" field.
+func (utu *UserTotpUpdate) ClearCreatedAt() *UserTotpUpdate {
+	utu.mutation.ClearCreatedAt()
+	return utu
+}
+
+// Mutation returns the UserTotpMutation object of the builder.
+func (utu *UserTotpUpdate) Mutation() *UserTotpMutation {
+	return utu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (utu *UserTotpUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(utu.hooks) == 0 {
+		affected, err = utu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*UserTotpMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			utu.mutation = mutation
+			affected, err = utu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(utu.hooks) - 1; i >= 0; i-- {
+			if utu.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = utu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, utu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (utu *UserTotpUpdate) SaveX(ctx context.Context) int {
+	affected, err := utu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (utu *UserTotpUpdate) Exec(ctx context.Context) error {
+	_, err := utu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (utu *UserTotpUpdate) ExecX(ctx context.Context) {
+	if err := utu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (utu *UserTotpUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   usertotp.Table,
+			Columns: usertotp.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: usertotp.FieldID,
+			},
+		},
+	}
+	if ps := utu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := utu.mutation.UserID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldUserID,
+		})
+	}
+	if value, ok := utu.mutation.AddedUserID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldUserID,
+		})
+	}
+	if value, ok := utu.mutation.Secret(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: usertotp.FieldSecret,
+		})
+	}
+	if value, ok := utu.mutation.ConfirmedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: usertotp.FieldConfirmedAt,
+		})
+	}
+	if utu.mutation.ConfirmedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: usertotp.FieldConfirmedAt,
+		})
+	}
+	if value, ok := utu.mutation.LastUsedStep(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldLastUsedStep,
+		})
+	}
+	if value, ok := utu.mutation.AddedLastUsedStep(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldLastUsedStep,
+		})
+	}
+	if utu.mutation.LastUsedStepCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Column: usertotp.FieldLastUsedStep,
+		})
+	}
+	if value, ok := utu.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: usertotp.FieldCreatedAt,
+		})
+	}
+	if utu.mutation.CreatedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: usertotp.FieldCreatedAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, utu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{usertotp.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// UserTotpUpdateOne is the builder for updating a single UserTotp entity.
+type UserTotpUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *UserTotpMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (utuo *UserTotpUpdateOne) SetUserID(i int64) *UserTotpUpdateOne {
+	utuo.mutation.ResetUserID()
+	utuo.mutation.SetUserID(i)
+	return utuo
+}
+
+// AddUserID adds i to the "user_id" field.
+func (utuo *UserTotpUpdateOne) AddUserID(i int64) *UserTotpUpdateOne {
+	utuo.mutation.AddUserID(i)
+	return utuo
+}
+
+// SetSecret sets the "secret" field.
+func (utuo *UserTotpUpdateOne) SetSecret(s string) *UserTotpUpdateOne {
+	utuo.mutation.SetSecret(s)
+	return utuo
+}
+
+// SetConfirmedAt sets the "confirmed_at" field.
+func (utuo *UserTotpUpdateOne) SetConfirmedAt(t time.Time) *UserTotpUpdateOne {
+	utuo.mutation.SetConfirmedAt(t)
+	return utuo
+}
+
+// SetNillableConfirmedAt sets the "confirmed_at" field if the given value is not nil.
+func (utuo *UserTotpUpdateOne) SetNillableConfirmedAt(t *time.Time) *UserTotpUpdateOne {
+	if t != nil {
+		utuo.SetConfirmedAt(*t)
+	}
+	return utuo
+}
+
+// ClearConfirmedAt clears the value of the "confirmed_at" field.
+func (utuo *UserTotpUpdateOne) ClearConfirmedAt() *UserTotpUpdateOne {
+	utuo.mutation.ClearConfirmedAt()
+	return utuo
+}
+
+// SetLastUsedStep sets the "last_used_step" field.
+func (utuo *UserTotpUpdateOne) SetLastUsedStep(i int64) *UserTotpUpdateOne {
+	utuo.mutation.ResetLastUsedStep()
+	utuo.mutation.SetLastUsedStep(i)
+	return utuo
+}
+
+// SetNillableLastUsedStep sets the "last_used_step" field if the given value is not nil.
+func (utuo *UserTotpUpdateOne) SetNillableLastUsedStep(i *int64) *UserTotpUpdateOne {
+	if i != nil {
+		utuo.SetLastUsedStep(*i)
+	}
+	return utuo
+}
+
+// AddLastUsedStep adds i to the "last_used_step" field.
+func (utuo *UserTotpUpdateOne) AddLastUsedStep(i int64) *UserTotpUpdateOne {
+	utuo.mutation.AddLastUsedStep(i)
+	return utuo
+}
+
+// ClearLastUsedStep clears the value of the "last_used_step" field.
+func (utuo *UserTotpUpdateOne) ClearLastUsedStep() *UserTotpUpdateOne {
+	utuo.mutation.ClearLastUsedStep()
+	return utuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (utuo *UserTotpUpdateOne) SetCreatedAt(t time.Time) *UserTotpUpdateOne {
+	utuo.mutation.SetCreatedAt(t)
+	return utuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (utuo *UserTotpUpdateOne) SetNillableCreatedAt(t *time.Time) *UserTotpUpdateOne {
+	if t != nil {
+		utuo.SetCreatedAt(*t)
+	}
+	return utuo
+}
+
+// ClearCreatedAt clears the value of the "created_at" field.
+func (utuo *UserTotpUpdateOne) ClearCreatedAt() *UserTotpUpdateOne {
+	utuo.mutation.ClearCreatedAt()
+	return utuo
+}
+
+// Mutation returns the UserTotpMutation object of the builder.
+func (utuo *UserTotpUpdateOne) Mutation() *UserTotpMutation {
+	return utuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (utuo *UserTotpUpdateOne) Select(field string, fields ...string) *UserTotpUpdateOne {
+	utuo.fields = append([]string{field}, fields...)
+	return utuo
+}
+
+// Save executes the query and returns the updated UserTotp entity.
+func (utuo *UserTotpUpdateOne) Save(ctx context.Context) (*UserTotp, error) {
+	var (
+		err  error
+		node *UserTotp
+	)
+	if len(utuo.hooks) == 0 {
+		node, err = utuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*UserTotpMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			utuo.mutation = mutation
+			node, err = utuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(utuo.hooks) - 1; i >= 0; i-- {
+			if utuo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = utuo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, utuo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*UserTotp)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from UserTotpMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (utuo *UserTotpUpdateOne) SaveX(ctx context.Context) *UserTotp {
+	node, err := utuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (utuo *UserTotpUpdateOne) Exec(ctx context.Context) error {
+	_, err := utuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (utuo *UserTotpUpdateOne) ExecX(ctx context.Context) {
+	if err := utuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (utuo *UserTotpUpdateOne) sqlSave(ctx context.Context) (_node *UserTotp, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   usertotp.Table,
+			Columns: usertotp.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt64,
+				Column: usertotp.FieldID,
+			},
+		},
+	}
+	id, ok := utuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "UserTotp.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := utuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, usertotp.FieldID)
+		for _, f := range fields {
+			if !usertotp.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != usertotp.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := utuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := utuo.mutation.UserID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldUserID,
+		})
+	}
+	if value, ok := utuo.mutation.AddedUserID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldUserID,
+		})
+	}
+	if value, ok := utuo.mutation.Secret(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: usertotp.FieldSecret,
+		})
+	}
+	if value, ok := utuo.mutation.ConfirmedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: usertotp.FieldConfirmedAt,
+		})
+	}
+	if utuo.mutation.ConfirmedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: usertotp.FieldConfirmedAt,
+		})
+	}
+	if value, ok := utuo.mutation.LastUsedStep(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldLastUsedStep,
+		})
+	}
+	if value, ok := utuo.mutation.AddedLastUsedStep(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Value:  value,
+			Column: usertotp.FieldLastUsedStep,
+		})
+	}
+	if utuo.mutation.LastUsedStepCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt64,
+			Column: usertotp.FieldLastUsedStep,
+		})
+	}
+	if value, ok := utuo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: usertotp.FieldCreatedAt,
+		})
+	}
+	if utuo.mutation.CreatedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: usertotp.FieldCreatedAt,
+		})
+	}
+	_node = &UserTotp{config: utuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, utuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{usertotp.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
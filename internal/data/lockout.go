@@ -0,0 +1,115 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rueian/rueidis"
+	"user-service/internal/biz"
+)
+
+type loginLockoutRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewLoginLockoutRepo(data *Data, logger log.Logger) biz.LoginLockoutRepo {
+	return &loginLockoutRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 同一对象的key使用相同的hash tag，保证集群模式下lua脚本中的key落在同一个slot
+var (
+	loginFailKey = func(subject string) string {
+		return fmt.Sprintf("user:lockout:{%s}:fail", subject)
+	}
+	loginLockKey = func(subject string) string {
+		return fmt.Sprintf("user:lockout:{%s}:lock", subject)
+	}
+	loginLockCountKey = func(subject string) string {
+		return fmt.Sprintf("user:lockout:{%s}:count", subject)
+	}
+)
+
+// KEYS: 失败次数, 锁定截止时间, 锁定次数  ARGV: 阈值, 统计窗口(ms), 首次锁定时长(ms), 最长锁定时长(ms), 当前时间(ms)
+// 达到阈值时锁定并返回锁定截止时间(ms)，否则返回0；最长锁定时长内再次触发锁定则时长翻倍
+var loginFailScript = rueidis.NewLuaScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if n < tonumber(ARGV[1]) then
+  return 0
+end
+redis.call('DEL', KEYS[1])
+local locks = redis.call('INCR', KEYS[3])
+redis.call('PEXPIRE', KEYS[3], ARGV[4])
+local d = math.floor(tonumber(ARGV[3]) * 2 ^ (locks - 1))
+if d > tonumber(ARGV[4]) then
+  d = tonumber(ARGV[4])
+end
+local untilMs = tonumber(ARGV[5]) + d
+redis.call('SET', KEYS[2], untilMs, 'PX', d)
+return untilMs
+`)
+
+func (r *loginLockoutRepo) LockedUntil(ctx context.Context, subjects ...string) (time.Time, error) {
+	var until time.Time
+	if len(subjects) == 0 {
+		return until, nil
+	}
+	cmds := make(rueidis.Commands, 0, len(subjects))
+	for _, s := range subjects {
+		cmds = append(cmds, r.data.rds.B().Get().Key(loginLockKey(s)).Build())
+	}
+	for _, resp := range r.data.rds.DoMulti(ctx, cmds...) {
+		ms, err := resp.AsInt64()
+		if rueidis.IsRedisNil(err) {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t := time.UnixMilli(ms); t.After(until) {
+			until = t
+		}
+	}
+	return until, nil
+}
+
+func (r *loginLockoutRepo) Fail(ctx context.Context, subject string, p biz.LockoutPolicy) (time.Time, error) {
+	ms, err := loginFailScript.Exec(ctx, r.data.rds,
+		[]string{loginFailKey(subject), loginLockKey(subject), loginLockCountKey(subject)},
+		[]string{
+			fmt.Sprintf("%d", p.MaxFailures),
+			fmt.Sprintf("%d", p.Window.Milliseconds()),
+			fmt.Sprintf("%d", p.BaseDuration.Milliseconds()),
+			fmt.Sprintf("%d", p.MaxDuration.Milliseconds()),
+			fmt.Sprintf("%d", time.Now().UnixMilli()),
+		},
+	).AsInt64()
+	if err != nil || ms == 0 {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms), nil
+}
+
+func (r *loginLockoutRepo) Clear(ctx context.Context, subjects ...string) error {
+	if len(subjects) == 0 {
+		return nil
+	}
+	cmds := make(rueidis.Commands, 0, len(subjects))
+	for _, s := range subjects {
+		cmds = append(cmds, r.data.rds.B().Del().Key(loginFailKey(s), loginLockKey(s), loginLockCountKey(s)).Build())
+	}
+	for _, resp := range r.data.rds.DoMulti(ctx, cmds...) {
+		if err := resp.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
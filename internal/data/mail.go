@@ -0,0 +1,150 @@
+package data
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/biz"
+	"user-service/internal/conf"
+)
+
+const (
+	MailDriverSmtp = "smtp"
+	MailDriverLog  = "log"
+
+	// 调用方未设置超时时，发送一封邮件的最长时间
+	defaultSmtpTimeout = 10 * time.Second
+)
+
+func NewMailer(c *conf.Data, logger log.Logger) (biz.Mailer, error) {
+	mc := c.GetMail()
+	switch mc.GetDriver() {
+	case MailDriverSmtp:
+		if mc.GetSmtp().GetHost() == "" {
+			return nil, fmt.Errorf("mail: smtp host is required")
+		}
+		return &smtpMailer{c: mc}, nil
+	case MailDriverLog:
+		// 邮件中含有验证及重置凭证，只允许显式配置
+		log.NewHelper(logger).Warn("邮件只打印到日志，不要在生产环境使用")
+		return &logMailer{log: log.NewHelper(logger)}, nil
+	case "":
+		return nil, fmt.Errorf("mail: driver is required")
+	default:
+		return nil, fmt.Errorf("mail: unknown driver %q", mc.GetDriver())
+	}
+}
+
+type smtpMailer struct {
+	c *conf.Data_Mail
+}
+
+// Send 与smtp.SendMail流程一致，但连接及读写均受ctx的截止时间约束
+func (m *smtpMailer) Send(ctx context.Context, mail *biz.Mail) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultSmtpTimeout)
+		defer cancel()
+	}
+	sc := m.c.GetSmtp()
+	port := int(sc.GetPort())
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(sc.GetHost(), strconv.Itoa(port))
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	// ctx提前取消时关闭连接，中断阻塞中的读写
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	c, err := smtp.NewClient(conn, sc.GetHost())
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: sc.GetHost()}); err != nil {
+			return err
+		}
+	}
+	if sc.GetUsername() != "" {
+		if err = c.Auth(smtp.PlainAuth("", sc.GetUsername(), sc.GetPassword(), sc.GetHost())); err != nil {
+			return err
+		}
+	}
+	if err = c.Mail(m.c.GetFrom()); err != nil {
+		return err
+	}
+	if err = c.Rcpt(mail.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(buildMessage(m.c.GetFrom(), mail)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func buildMessage(from string, mail *biz.Mail) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + mail.To + "\r\n")
+	b.WriteString("Subject: =?UTF-8?B?" + base64Encode(mail.Subject) + "?=\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	// 按RFC 2045每行不超过76个字符
+	body := base64Encode(mail.Body)
+	for len(body) > 76 {
+		b.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	b.WriteString(body)
+	return []byte(b.String())
+}
+
+func base64Encode(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// logMailer 只打印邮件内容，用于开发及测试环境
+type logMailer struct {
+	log *log.Helper
+}
+
+func (m *logMailer) Send(ctx context.Context, mail *biz.Mail) error {
+	m.log.WithContext(ctx).Infof("发送邮件至%s，主题：%s\n%s", mail.To, mail.Subject, mail.Body)
+	return nil
+}
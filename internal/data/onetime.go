@@ -0,0 +1,70 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rueian/rueidis"
+	"user-service/internal/biz"
+)
+
+type oneTimeTokenRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewOneTimeTokenRepo(data *Data, logger log.Logger) biz.OneTimeTokenRepo {
+	return &oneTimeTokenRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+var oneTimeTokenKey = func(purpose string, userId int64) string {
+	return fmt.Sprintf("user:ott:{%d}:%s", userId, purpose)
+}
+
+var oneTimeTokenCooldownKey = func(purpose string, userId int64) string {
+	return oneTimeTokenKey(purpose, userId) + ":cooldown"
+}
+
+// KEYS: token  ARGV: token哈希
+// 哈希一致时删除并返回1，否则返回0
+var consumeOneTimeTokenScript = rueidis.NewLuaScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  redis.call('DEL', KEYS[1])
+  return 1
+end
+return 0
+`)
+
+func (r *oneTimeTokenRepo) Save(ctx context.Context, purpose string, userId int64, tokenHash string, ttl time.Duration) error {
+	return r.data.rds.Do(ctx, r.data.rds.B().Set().
+		Key(oneTimeTokenKey(purpose, userId)).
+		Value(tokenHash).
+		PxMilliseconds(ttl.Milliseconds()).
+		Build()).Error()
+}
+
+func (r *oneTimeTokenRepo) Consume(ctx context.Context, purpose string, userId int64, tokenHash string) (bool, error) {
+	n, err := consumeOneTimeTokenScript.Exec(ctx, r.data.rds,
+		[]string{oneTimeTokenKey(purpose, userId)},
+		[]string{tokenHash},
+	).AsInt64()
+	return n == 1, err
+}
+
+func (r *oneTimeTokenRepo) Cooldown(ctx context.Context, purpose string, userId int64, interval time.Duration) (bool, error) {
+	err := r.data.rds.Do(ctx, r.data.rds.B().Set().
+		Key(oneTimeTokenCooldownKey(purpose, userId)).
+		Value("1").
+		Nx().
+		PxMilliseconds(interval.Milliseconds()).
+		Build()).Error()
+	if rueidis.IsRedisNil(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/biz"
+	"user-service/internal/data/ent/permission"
+	"user-service/internal/data/ent/role"
+	"user-service/internal/data/ent/user"
+	ex "user-service/internal/pkg/errors"
+	"user-service/internal/pkg/errors/enterr"
+)
+
+type roleRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewRoleRepo(data *Data, logger log.Logger) biz.RoleRepo {
+	return &roleRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r roleRepo) ListPermissionCodes(ctx context.Context, userId int64) ([]string, error) {
+	codes, err := r.data.db.User.
+		Query().
+		Where(user.ID(userId)).
+		QueryRoles().
+		QueryPermissions().
+		Unique(true).
+		Select(permission.FieldCode).
+		Strings(ctx)
+	return codes, enterr.Convert(err, nil)
+}
+
+func (r roleRepo) GrantRole(ctx context.Context, userId int64, name string) error {
+	roleId, err := r.getRoleId(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// 已拥有该角色时直接返回，避免关联表主键冲突
+	granted, err := r.data.User(ctx).
+		Query().
+		Where(user.ID(userId), user.HasRolesWith(role.ID(roleId))).
+		Exist(ctx)
+	if err != nil || granted {
+		return enterr.Convert(err, nil)
+	}
+
+	err = r.data.User(ctx).
+		UpdateOneID(userId).
+		AddRoleIDs(roleId).
+		Exec(ctx)
+	return enterr.Convert(err, ex.UserNotFound)
+}
+
+func (r roleRepo) RevokeRole(ctx context.Context, userId int64, name string) error {
+	roleId, err := r.getRoleId(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = r.data.User(ctx).
+		UpdateOneID(userId).
+		RemoveRoleIDs(roleId).
+		Exec(ctx)
+	return enterr.Convert(err, ex.UserNotFound)
+}
+
+func (r roleRepo) getRoleId(ctx context.Context, name string) (int64, error) {
+	id, err := r.data.Role(ctx).
+		Query().
+		Where(role.Name(name)).
+		OnlyID(ctx)
+	return id, enterr.Convert(err, ex.RoleNotFound)
+}
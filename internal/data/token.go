@@ -0,0 +1,168 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rueian/rueidis"
+	"user-service/internal/biz"
+)
+
+type refreshTokenRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewRefreshTokenRepo(data *Data, logger log.Logger) biz.RefreshTokenRepo {
+	return &refreshTokenRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// 同一用户的key使用相同的hash tag，保证集群模式下lua脚本中的key落在同一个slot
+var (
+	refreshTokenKey = func(userId int64, tokenId string) string {
+		return fmt.Sprintf("user:token:{%d}:refresh:%s", userId, tokenId)
+	}
+	tokenFamilyKey = func(userId int64, familyId string) string {
+		return fmt.Sprintf("user:token:{%d}:family:%s", userId, familyId)
+	}
+	tokenFamiliesKey = func(userId int64) string {
+		return fmt.Sprintf("user:token:{%d}:families", userId)
+	}
+	// 用户签发过的refresh token，物理删除用户时据此清理
+	tokenRefreshesKey = func(userId int64) string {
+		return fmt.Sprintf("user:token:{%d}:refreshes", userId)
+	}
+)
+
+var (
+	// KEYS: refresh token, family, 用户的family集合, 用户的token集合  ARGV: familyId, ttl(ms), tokenId
+	saveRefreshTokenScript = rueidis.NewLuaScript(`
+redis.call('HSET', KEYS[1], 'fid', ARGV[1], 'used', '0')
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+redis.call('SET', KEYS[2], '1', 'PX', ARGV[2])
+redis.call('SADD', KEYS[3], ARGV[1])
+redis.call('PEXPIRE', KEYS[3], ARGV[2])
+redis.call('SADD', KEYS[4], ARGV[3])
+redis.call('PEXPIRE', KEYS[4], ARGV[2])
+return 1
+`)
+
+	// KEYS: refresh token, family, 用户的family集合  ARGV: familyId
+	// 返回 0:token无效或family已吊销 1:首次使用 2:重复使用（同时吊销整个family）
+	useRefreshTokenScript = rueidis.NewLuaScript(`
+if redis.call('EXISTS', KEYS[2]) == 0 then
+  return 0
+end
+local values = redis.call('HMGET', KEYS[1], 'fid', 'used')
+if not values[1] or values[1] ~= ARGV[1] then
+  return 0
+end
+if values[2] == '1' then
+  redis.call('DEL', KEYS[2])
+  redis.call('SREM', KEYS[3], ARGV[1])
+  return 2
+end
+redis.call('HSET', KEYS[1], 'used', '1')
+return 1
+`)
+
+	// KEYS: 用户的family集合, 各family  ARGV: 与KEYS[2..]对应的familyId
+	// 只移除读取到的family，期间新登录产生的family不受影响
+	revokeFamiliesScript = rueidis.NewLuaScript(`
+for i = 2, #KEYS do
+  redis.call('DEL', KEYS[i])
+  redis.call('SREM', KEYS[1], ARGV[i - 1])
+end
+return #KEYS - 1
+`)
+)
+
+func (r *refreshTokenRepo) Save(ctx context.Context, t *biz.RefreshToken) error {
+	ttl := time.Until(t.ExpiresAt).Milliseconds()
+	if ttl <= 0 {
+		return nil
+	}
+	return saveRefreshTokenScript.Exec(ctx, r.data.rds,
+		[]string{
+			refreshTokenKey(t.UserId, t.Id),
+			tokenFamilyKey(t.UserId, t.FamilyId),
+			tokenFamiliesKey(t.UserId),
+			tokenRefreshesKey(t.UserId),
+		},
+		[]string{t.FamilyId, fmt.Sprintf("%d", ttl), t.Id},
+	).Error()
+}
+
+func (r *refreshTokenRepo) Use(ctx context.Context, t *biz.RefreshToken) (biz.RefreshTokenState, error) {
+	state, err := useRefreshTokenScript.Exec(ctx, r.data.rds,
+		[]string{
+			refreshTokenKey(t.UserId, t.Id),
+			tokenFamilyKey(t.UserId, t.FamilyId),
+			tokenFamiliesKey(t.UserId),
+		},
+		[]string{t.FamilyId},
+	).AsInt64()
+	if err != nil {
+		return biz.RefreshTokenInvalid, err
+	}
+	return biz.RefreshTokenState(state), nil
+}
+
+func (r *refreshTokenRepo) RevokeFamily(ctx context.Context, userId int64, familyId string) error {
+	cmds := make(rueidis.Commands, 0, 2)
+	cmds = append(cmds, r.data.rds.B().Del().Key(tokenFamilyKey(userId, familyId)).Build())
+	cmds = append(cmds, r.data.rds.B().Srem().Key(tokenFamiliesKey(userId)).Member(familyId).Build())
+	for _, resp := range r.data.rds.DoMulti(ctx, cmds...) {
+		if err := resp.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *refreshTokenRepo) RevokeAll(ctx context.Context, userId int64) error {
+	families, err := r.data.rds.Do(ctx, r.data.rds.B().Smembers().Key(tokenFamiliesKey(userId)).Build()).AsStrSlice()
+	if err != nil {
+		return err
+	}
+	if len(families) == 0 {
+		return nil
+	}
+	// 脚本访问的key全部通过KEYS传入，集群模式下由hash tag保证落在同一slot
+	keys := make([]string, 0, len(families)+1)
+	keys = append(keys, tokenFamiliesKey(userId))
+	for _, fid := range families {
+		keys = append(keys, tokenFamilyKey(userId, fid))
+	}
+	return revokeFamiliesScript.Exec(ctx, r.data.rds, keys, families).Error()
+}
+
+// deleteUserTokens 删除用户的全部refresh token及family，用于物理删除用户
+func deleteUserTokens(ctx context.Context, rds rueidis.Client, userId int64) error {
+	resps := rds.DoMulti(ctx,
+		rds.B().Smembers().Key(tokenFamiliesKey(userId)).Build(),
+		rds.B().Smembers().Key(tokenRefreshesKey(userId)).Build(),
+	)
+	families, err := resps[0].AsStrSlice()
+	if err != nil {
+		return err
+	}
+	tokens, err := resps[1].AsStrSlice()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(families)+len(tokens)+2)
+	keys = append(keys, tokenFamiliesKey(userId), tokenRefreshesKey(userId))
+	for _, fid := range families {
+		keys = append(keys, tokenFamilyKey(userId, fid))
+	}
+	for _, tid := range tokens {
+		keys = append(keys, refreshTokenKey(userId, tid))
+	}
+	return rds.Do(ctx, rds.B().Del().Key(keys...).Build()).Error()
+}
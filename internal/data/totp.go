@@ -0,0 +1,132 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/biz"
+	"user-service/internal/data/ent"
+	"user-service/internal/data/ent/recoverycode"
+	"user-service/internal/data/ent/usertotp"
+	ex "user-service/internal/pkg/errors"
+	"user-service/internal/pkg/errors/enterr"
+)
+
+type totpRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewTotpRepo(data *Data, logger log.Logger) biz.TotpRepo {
+	return &totpRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r totpRepo) GetByUserId(ctx context.Context, userId int64) (*biz.UserTotp, error) {
+	t, err := r.data.UserTotp(ctx).
+		Query().
+		Where(usertotp.UserID(userId)).
+		Only(ctx)
+	if err != nil {
+		return nil, enterr.Convert(err, ex.TotpNotEnrolled)
+	}
+	return ConvertToUserTotp(t), nil
+}
+
+func (r totpRepo) Save(ctx context.Context, t *biz.UserTotp) error {
+	// 用户唯一，先删除未确认的旧绑定
+	if _, err := r.data.UserTotp(ctx).
+		Delete().
+		Where(usertotp.UserID(t.UserId)).
+		Exec(ctx); err != nil {
+		return enterr.Convert(err, nil)
+	}
+	err := r.data.UserTotp(ctx).
+		Create().
+		SetUserTotp(t).
+		Exec(ctx)
+	return enterr.Convert(err, nil)
+}
+
+func (r totpRepo) Confirm(ctx context.Context, userId int64) error {
+	n, err := r.data.UserTotp(ctx).
+		Update().
+		Where(usertotp.UserID(userId)).
+		SetConfirmedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return enterr.Convert(err, ex.TotpNotEnrolled)
+	}
+	if n == 0 {
+		return ex.TotpNotEnrolled
+	}
+	return nil
+}
+
+func (r totpRepo) UseStep(ctx context.Context, userId, step int64) (bool, error) {
+	n, err := r.data.UserTotp(ctx).
+		Update().
+		Where(
+			usertotp.UserID(userId),
+			usertotp.Or(usertotp.LastUsedStepIsNil(), usertotp.LastUsedStepLT(step)),
+		).
+		SetLastUsedStep(step).
+		Save(ctx)
+	return n > 0, enterr.Convert(err, nil)
+}
+
+func (r totpRepo) ReplaceRecoveryCodes(ctx context.Context, userId int64, hashes []string) error {
+	if _, err := r.data.RecoveryCode(ctx).
+		Delete().
+		Where(recoverycode.UserID(userId)).
+		Exec(ctx); err != nil {
+		return enterr.Convert(err, nil)
+	}
+	now := time.Now()
+	builders := make([]*ent.RecoveryCodeCreate, 0, len(hashes))
+	for _, h := range hashes {
+		builders = append(builders, r.data.RecoveryCode(ctx).
+			Create().
+			SetUserID(userId).
+			SetCodeHash(h).
+			SetCreatedAt(now))
+	}
+	err := r.data.RecoveryCode(ctx).
+		CreateBulk(builders...).
+		Exec(ctx)
+	return enterr.Convert(err, nil)
+}
+
+func (r totpRepo) UseRecoveryCode(ctx context.Context, userId int64, hash string) (bool, error) {
+	n, err := r.data.RecoveryCode(ctx).
+		Update().
+		Where(
+			recoverycode.UserID(userId),
+			recoverycode.CodeHash(hash),
+			recoverycode.UsedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	return n > 0, enterr.Convert(err, nil)
+}
+
+func ConvertToUserTotp(t *ent.UserTotp) *biz.UserTotp {
+	bt := &biz.UserTotp{
+		Id:     t.ID,
+		UserId: t.UserID,
+		Secret: t.Secret,
+	}
+	if !t.ConfirmedAt.IsZero() {
+		bt.ConfirmedAt = &t.ConfirmedAt
+	}
+	if t.LastUsedStep != 0 {
+		bt.LastUsedStep = &t.LastUsedStep
+	}
+	if !t.CreatedAt.IsZero() {
+		bt.CreatedAt = &t.CreatedAt
+	}
+	return bt
+}
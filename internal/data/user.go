@@ -2,114 +2,370 @@ package data
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/lovechung/go-kit/util/pagination"
+	"golang.org/x/sync/errgroup"
 	"time"
 	"user-service/internal/biz"
+	"user-service/internal/conf"
 	"user-service/internal/data/ent"
 	"user-service/internal/data/ent/predicate"
+	"user-service/internal/data/ent/recoverycode"
+	"user-service/internal/data/ent/schema"
 	"user-service/internal/data/ent/user"
+	"user-service/internal/data/ent/usertotp"
 	ex "user-service/internal/pkg/errors"
+	"user-service/internal/pkg/errors/enterr"
 )
 
 type userRepo struct {
-	data *Data
-	log  *log.Helper
+	data  *Data
+	cache *jsonCache
+	// ids 未开启布隆过滤器时为nil
+	ids *userIdFilter
+	log *log.Helper
 }
 
-func NewUserRepo(data *Data, logger log.Logger) biz.UserRepo {
-	return &userRepo{
-		data: data,
-		log:  log.NewHelper(logger),
+func NewUserRepo(data *Data, c *conf.Data, logger log.Logger) biz.UserRepo {
+	r := &userRepo{
+		data:  data,
+		cache: newJSONCache(data.rds, "user_info", ex.UserNotFound, c.GetCache(), logger),
+		log:   log.NewHelper(logger),
 	}
+	if bloom := c.GetCache().GetBloom(); bloom.GetEnabled() {
+		// 与用户缓存共用熔断器，redis故障时同样跳过
+		r.ids = newUserIdFilter(data.rds, data.db, r.cache.breaker, bloom, logger)
+	}
+	return r
 }
 
+const usernameKeyBackfillBatch = 500
+
 var userCacheKey = func(userId string) string {
 	return "user:info:" + userId
 }
 
-func (r userRepo) ListUser(ctx context.Context, page, pageSize int, username *string) ([]*biz.User, int, error) {
+// biz层可见字段与数据库列的对应关系
+var userColumns = map[string]string{
+	biz.UserFieldId:        user.FieldID,
+	biz.UserFieldUsername:  user.FieldUsername,
+	biz.UserFieldCreatedAt: user.FieldCreatedAt,
+	biz.UserFieldUpdatedAt: user.FieldUpdatedAt,
+	biz.UserFieldStatus:    user.FieldStatus,
+	biz.UserFieldEmail:     user.FieldEmail,
+	// 对外只返回是否已验证
+	biz.UserFieldEmailVerified: user.FieldEmailVerifiedAt,
+	biz.UserFieldNickname:      user.FieldNickname,
+	biz.UserFieldAvatarUrl:     user.FieldAvatarURL,
+	biz.UserFieldPhone:         user.FieldPhone,
+	biz.UserFieldLocale:        user.FieldLocale,
+	biz.UserFieldTimezone:      user.FieldTimezone,
+	biz.UserFieldBio:           user.FieldBio,
+	biz.UserFieldAttributes:    user.FieldAttributes,
+	biz.UserFieldVersion:       user.FieldVersion,
+}
+
+// selectColumns 始终查询账号状态及创建时间，供biz层判断账号是否可读及生成分页游标
+func selectColumns(fields []string) []string {
+	columns := make([]string, 0, len(fields)+2)
+	columns = append(columns, user.FieldStatus, user.FieldCreatedAt)
+	for _, f := range fields {
+		if c, ok := userColumns[f]; ok && c != user.FieldStatus && c != user.FieldCreatedAt {
+			columns = append(columns, c)
+		}
+	}
+	return columns
+}
+
+func (r userRepo) ListUser(ctx context.Context, lq *biz.UserListQuery) (*biz.UserPage, error) {
 	// 该方法演示单表分页多条件查询
 	q := r.data.db.User.Query()
 
 	// 组装查询条件
 	cond := make([]predicate.User, 0)
-	if username != nil {
-		cond = append(cond, user.UsernameContains(*username))
+	if lq.Username != nil {
+		cond = append(cond, user.UsernameContains(*lq.Username))
 	}
 	if len(cond) > 0 {
 		q.Where(cond...)
 	}
+	countQuery := q.Clone()
 
-	// 查询总数
-	total := q.CountX(ctx)
+	// 游标分页按(created_at, id)定位，向前翻页时反向排序后再翻转
+	order := []ent.OrderFunc{ent.Desc(user.FieldCreatedAt), ent.Desc(user.FieldID)}
+	if c := lq.Cursor; c != nil {
+		if c.Backward {
+			q.Where(user.Or(
+				user.CreatedAtGT(c.CreatedAt),
+				user.And(user.CreatedAtEQ(c.CreatedAt), user.IDGT(c.Id)),
+			))
+			order = []ent.OrderFunc{ent.Asc(user.FieldCreatedAt), ent.Asc(user.FieldID)}
+		} else {
+			q.Where(user.Or(
+				user.CreatedAtLT(c.CreatedAt),
+				user.And(user.CreatedAtEQ(c.CreatedAt), user.IDLT(c.Id)),
+			))
+		}
+	} else {
+		q.Offset(pagination.GetOffset(lq.Page, lq.PageSize))
+	}
 
-	// 查询列表
-	users := q.Offset(pagination.GetOffset(page, pageSize)).
-		Limit(pageSize).
-		Order(ent.Desc(user.FieldCreatedAt)).
-		AllX(ctx)
+	// 总数与列表并发查询，任一失败时取消另一个
+	page := &biz.UserPage{}
+	g, gctx := errgroup.WithContext(ctx)
+	if !lq.SkipCount {
+		g.Go(func() error {
+			total, err := countQuery.Count(gctx)
+			page.Total = total
+			return err
+		})
+	}
+	var users []*ent.User
+	g.Go(func() error {
+		var err error
+		// 多查一条用于判断是否还有下一页
+		users, err = q.Select(selectColumns(lq.Fields)...).
+			Limit(lq.PageSize + 1).
+			Order(order...).
+			All(gctx)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, enterr.Convert(err, nil)
+	}
 
 	// 组装返回参数
-	list := make([]*biz.User, 0)
+	if len(users) > lq.PageSize {
+		users = users[:lq.PageSize]
+		page.HasMore = true
+	}
+	if lq.Cursor != nil && lq.Cursor.Backward {
+		for i, j := 0, len(users)-1; i < j; i, j = i+1, j-1 {
+			users[i], users[j] = users[j], users[i]
+		}
+	}
+	page.List = make([]*biz.User, 0, len(users))
 	for _, u := range users {
-		list = append(list, ConvertToUser(u))
+		page.List = append(page.List, ConvertToUser(u))
 	}
-	return list, total, nil
+	return page, nil
 }
 
-func (r userRepo) GetById(ctx context.Context, id int64) (*biz.User, error) {
-	// 先从缓存中取
+func (r userRepo) GetById(ctx context.Context, id int64, fields []string) (*biz.User, error) {
+	if r.ids != nil && !r.ids.MightExist(ctx, id) {
+		return nil, ex.UserNotFound
+	}
+	// 缓存中存放的是全部可见字段
 	cacheKey := userCacheKey(fmt.Sprintf("%d", id))
-	u, err := r.getUserCache(ctx, cacheKey)
-
-	if err != nil {
-		// 缓存没有命中，则从数据库取
-		u, err = r.data.db.User.Get(ctx, id)
+	cached := &ent.User{}
+	if biz.IsAllUserFields(fields) {
+		err := r.cache.Fetch(ctx, cacheKey, cached, func(ctx context.Context) (interface{}, error) {
+			return r.queryUser(ctx, id, fields)
+		})
 		if err != nil {
-			return nil, ex.UserNotFound
+			return nil, err
 		}
-		// 重新刷入缓存
-		r.setUserCache(ctx, u, cacheKey)
+		return ConvertToUser(cached), nil
 	}
-	return ConvertToUser(u), err
+
+	// 只查询部分字段时，缓存未命中则只查询请求的字段，不刷入缓存
+	if err := r.cache.Get(ctx, cacheKey, cached); err == nil {
+		return ConvertToUser(cached), nil
+	} else if errors.Is(err, ex.UserNotFound) {
+		return nil, err
+	}
+	u, err := r.queryUser(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertToUser(u), nil
 }
 
-func (r userRepo) GetUsername(ctx context.Context, id int64) (string, error) {
+func (r userRepo) queryUser(ctx context.Context, id int64, fields []string) (*ent.User, error) {
 	u, err := r.data.db.User.
 		Query().
-		Select(user.FieldUsername).
+		Select(selectColumns(fields)...).
 		Where(user.ID(id)).
 		Only(ctx)
 	if err != nil {
-		return "", ex.UserNotFound
+		return nil, enterr.Convert(err, ex.UserNotFound)
 	}
+	return u, nil
+}
 
-	// 模拟一个异常
-	if id == 1 {
-		return "", ex.UserIsFreeze
+func (r userRepo) GetByUsername(ctx context.Context, username string) (*biz.User, error) {
+	// 未回填唯一键的历史数据仍按原用户名匹配
+	u, err := r.data.db.User.
+		Query().
+		Where(user.Or(
+			user.UsernameKey(biz.NormalizeUsername(username)),
+			user.And(user.UsernameKeyIsNil(), user.Username(username)),
+		)).
+		First(ctx)
+	if err != nil {
+		return nil, enterr.Convert(err, ex.UserNotFound)
 	}
+	return ConvertToUser(u), nil
+}
 
-	return u.Username, nil
+func (r userRepo) GetByEmail(ctx context.Context, email string) (*biz.User, error) {
+	u, err := r.data.db.User.
+		Query().
+		Where(user.Email(email)).
+		Only(ctx)
+	if err != nil {
+		return nil, enterr.Convert(err, ex.UserNotFound)
+	}
+	return ConvertToUser(u), nil
+}
+
+func (r userRepo) ExistsUsernameKey(ctx context.Context, key string) (bool, error) {
+	exists, err := r.data.db.User.
+		Query().
+		Where(user.UsernameKey(key)).
+		Exist(schema.SkipSoftDelete(ctx))
+	return exists, enterr.Convert(err, nil)
 }
 
-func (r userRepo) GetUsernameBatch(ctx context.Context, ids []int64) (map[int64]string, error) {
+// BackfillUsernameKeys 纯ASCII的用户名已由migrations/0005_username_key.sql回填，这里处理其余的用户名
+func (r userRepo) BackfillUsernameKeys(ctx context.Context) error {
+	ctx = schema.SkipSoftDelete(ctx)
+	var after int64
+	for {
+		users, err := r.data.db.User.
+			Query().
+			Select(user.FieldUsername).
+			Where(user.IDGT(after), user.UsernameKeyIsNil(), user.UsernameNotNil()).
+			Order(ent.Asc(user.FieldID)).
+			Limit(usernameKeyBackfillBatch).
+			All(ctx)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return nil
+		}
+		for _, u := range users {
+			key := biz.NormalizeUsername(u.Username)
+			if key == "" {
+				continue
+			}
+			err = r.data.db.User.
+				Update().
+				Where(user.ID(u.ID), user.UsernameKeyIsNil()).
+				SetUsernameKey(key).
+				Exec(ctx)
+			if ent.IsConstraintError(err) {
+				// 与其他账号规范化后重名，需人工处理
+				r.log.Warnf("用户id=%d的用户名%q与已有账号冲突，未回填唯一键", u.ID, u.Username)
+			} else if err != nil {
+				return err
+			}
+		}
+		after = users[len(users)-1].ID
+	}
+}
+
+// BuildIdFilter 未开启布隆过滤器时不做处理
+func (r userRepo) BuildIdFilter(ctx context.Context, force bool) error {
+	if r.ids == nil {
+		return nil
+	}
+	return r.ids.Build(ctx, force)
+}
+
+// GetUsername 与GetById共用用户信息缓存，未命中时查询全部可见字段并刷入缓存
+func (r userRepo) GetUsername(ctx context.Context, id int64) (*biz.User, error) {
+	return r.GetById(ctx, id, biz.UserFields)
+}
+
+// GetUsernameBatch 先批量读取缓存，未命中的id查询全部可见字段并刷入缓存
+func (r userRepo) GetUsernameBatch(ctx context.Context, ids []int64) ([]*biz.User, error) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, userCacheKey(fmt.Sprintf("%d", id)))
+	}
+	vals, miss := r.cache.MGet(ctx, keys, func() interface{} { return &ent.User{} })
+
+	list := make([]*biz.User, 0, len(ids))
+	for _, v := range vals {
+		if v != nil {
+			list = append(list, ConvertToUser(v.(*ent.User)))
+		}
+	}
+	if len(miss) == 0 {
+		return list, nil
+	}
+
+	missIds := make([]int64, 0, len(miss))
+	for _, i := range miss {
+		missIds = append(missIds, ids[i])
+	}
+	missKeys := make([]string, 0, len(miss))
+	for _, i := range miss {
+		missKeys = append(missKeys, keys[i])
+	}
+	// 查询前读取失效计数，查询期间被修改的用户不写入缓存；读取失败时只查询不写入
+	gens, genErr := r.cache.Gens(ctx, missKeys)
+	start := time.Now()
 	users, err := r.data.db.User.
 		Query().
-		Select(user.FieldID, user.FieldUsername).
-		Where(user.IDIn(ids...)).
+		Select(selectColumns(biz.UserFields)...).
+		Where(user.IDIn(missIds...)).
 		All(ctx)
 	if err != nil {
-		return nil, err
+		return nil, enterr.Convert(err, nil)
+	}
+	delta := time.Since(start)
+	genOf := make(map[int64]string, len(missIds))
+	if genErr == nil {
+		for i, id := range missIds {
+			genOf[id] = gens[i]
+		}
 	}
-
-	nameMap := make(map[int64]string)
 	for _, u := range users {
-		nameMap[u.ID] = u.Username
+		if gen, ok := genOf[u.ID]; ok {
+			r.cache.Set(ctx, userCacheKey(fmt.Sprintf("%d", u.ID)), gen, u, delta)
+		}
+		list = append(list, ConvertToUser(u))
+	}
+	return list, nil
+}
+
+func (r userRepo) GetStatus(ctx context.Context, id int64) (biz.UserStatus, error) {
+	u, err := r.data.User(ctx).
+		Query().
+		Select(user.FieldStatus).
+		Where(user.ID(id)).
+		Only(ctx)
+	if err != nil {
+		return "", enterr.Convert(err, ex.UserNotFound)
 	}
-	return nameMap, nil
+	return u.Status, nil
+}
+
+func (r userRepo) UpdateStatus(ctx context.Context, id int64, from, to biz.UserStatus, reason string, actor int64) error {
+	n, err := r.data.User(ctx).
+		Update().
+		Where(user.ID(id), user.StatusEQ(from)).
+		SetStatus(to).
+		SetStatusReason(reason).
+		SetStatusChangedBy(actor).
+		SetStatusChangedAt(time.Now()).
+		AddVersion(1).
+		Save(ctx)
+	if err != nil {
+		return enterr.Convert(err, ex.UserNotFound)
+	}
+	// 状态已被其他请求变更
+	if n == 0 {
+		return ex.InvalidStatusTransition
+	}
+	// 缓存中存有账号状态，需一并删除
+	r.invalidateCache(ctx, id)
+	return nil
 }
 
 func (r userRepo) Save(ctx context.Context, u *biz.User) (int64, error) {
@@ -118,51 +374,237 @@ func (r userRepo) Save(ctx context.Context, u *biz.User) (int64, error) {
 		SetUser(u).
 		SetCreatedAt(time.Now()).
 		Save(ctx)
-	return rsp.ID, err
+	if err != nil {
+		return 0, enterr.Convert(err, nil)
+	}
+	// 清除该id可能残留的不存在标记
+	r.invalidateCache(ctx, rsp.ID)
+	if r.ids != nil {
+		r.data.AfterCommit(ctx, func(ctx context.Context) {
+			r.ids.Add(ctx, rsp.ID)
+		})
+	}
+	return rsp.ID, nil
 }
 
-func (r userRepo) Update(ctx context.Context, u *biz.User) error {
+func (r userRepo) Update(ctx context.Context, u *biz.User, clears []string) error {
 	// 带有事务的操作
-	err := r.data.User(ctx).
+	q := r.data.User(ctx).
 		Update().
 		Where(user.ID(u.Id)).
 		SetUser(u).
-		SetUpdatedAt(time.Now()).
+		SetUpdatedAt(time.Now())
+	// 版本号只能自增，不使用SetUser设置的期望值
+	q.Mutation().ResetVersion()
+	q.AddVersion(1)
+	if u.Version != nil {
+		q.Where(user.Version(*u.Version))
+	}
+	emailChanged := u.Email != nil
+	for _, f := range clears {
+		c, ok := userColumns[f]
+		if !ok {
+			return ex.InvalidFieldMask.WithMetadata(map[string]string{"field": f})
+		}
+		if err := q.Mutation().ClearField(c); err != nil {
+			return ex.InvalidFieldMask.WithMetadata(map[string]string{"field": f})
+		}
+		emailChanged = emailChanged || c == user.FieldEmail
+	}
+	// 修改邮箱后需重新验证
+	if emailChanged {
+		q.ClearEmailVerifiedAt()
+	}
+	n, err := q.Save(ctx)
+	if err != nil {
+		return enterr.Convert(err, ex.UserNotFound)
+	}
+	if n == 0 {
+		return r.updateMissErr(ctx, u.Id)
+	}
+	// 缓存中存有用户资料，需一并删除
+	r.invalidateCache(ctx, u.Id)
+	return nil
+}
+
+// updateMissErr 条件更新没有命中时，区分用户不存在与版本号不一致
+func (r userRepo) updateMissErr(ctx context.Context, id int64) error {
+	cur, err := r.data.User(ctx).
+		Query().
+		Select(user.FieldVersion).
+		Where(user.ID(id)).
+		Only(ctx)
+	if err != nil {
+		return enterr.Convert(err, ex.UserNotFound)
+	}
+	return ex.VersionConflict.WithMetadata(map[string]string{
+		"current_version": fmt.Sprintf("%d", cur.Version),
+	})
+}
+
+func (r userRepo) MarkEmailVerified(ctx context.Context, id int64, email string) error {
+	n, err := r.data.User(ctx).
+		Update().
+		Where(user.ID(id), user.Email(email), user.EmailVerifiedAtIsNil()).
+		SetEmailVerifiedAt(time.Now()).
+		AddVersion(1).
+		Save(ctx)
+	if err != nil {
+		return enterr.Convert(err, ex.UserNotFound)
+	}
+	// 邮箱已被修改
+	if n == 0 {
+		return ex.VerificationTokenInvalid
+	}
+	r.invalidateCache(ctx, id)
+	return nil
+}
+
+func (r userRepo) UpdatePassword(ctx context.Context, id int64, password string) error {
+	err := r.data.User(ctx).
+		UpdateOneID(id).
+		SetPassword(password).
+		AddVersion(1).
 		Exec(ctx)
-	// 模拟一个异常
-	if u.Password != nil && *u.Password == "123456" {
-		err = ex.UserNotFound
+	if err != nil {
+		return enterr.Convert(err, ex.UserNotFound)
 	}
-	return err
+	// 缓存中存有版本号
+	r.invalidateCache(ctx, id)
+	return nil
 }
 
+// Delete 软删除，数据由定时任务在保留期过后物理删除
 func (r userRepo) Delete(ctx context.Context, id int64) error {
-	return r.data.db.User.
-		DeleteOneID(id).
+	err := r.data.User(ctx).
+		UpdateOneID(id).
+		SetDeletedAt(time.Now()).
 		Exec(ctx)
+	if err != nil {
+		return enterr.Convert(err, ex.UserNotFound)
+	}
+	r.invalidateCache(ctx, id)
+	return nil
+}
+
+func (r userRepo) Restore(ctx context.Context, id int64) error {
+	n, err := r.data.User(ctx).
+		Update().
+		Where(user.ID(id), user.DeletedAtNotNil()).
+		ClearDeletedAt().
+		Save(schema.SkipSoftDelete(ctx))
+	if err != nil {
+		return enterr.Convert(err, ex.UserNotFound)
+	}
+	if n == 0 {
+		return ex.UserNotFound
+	}
+	r.invalidateCache(ctx, id)
+	return nil
+}
+
+func (r userRepo) Purge(ctx context.Context, before time.Time, limit int) (int, error) {
+	ctx = schema.SkipSoftDelete(ctx)
+	ids, err := r.data.db.User.
+		Query().
+		Where(user.DeletedAtNotNil(), user.DeletedAtLT(before)).
+		Limit(limit).
+		IDs(ctx)
+	if err != nil || len(ids) == 0 {
+		return 0, enterr.Convert(err, nil)
+	}
+	tx, err := r.data.db.Tx(ctx)
+	if err != nil {
+		return 0, enterr.Convert(err, nil)
+	}
+	// 用户角色关联由外键级联删除，TOTP及恢复码没有外键，在同一事务中删除
+	if _, err = tx.UserTotp.Delete().Where(usertotp.UserIDIn(ids...)).Exec(ctx); err != nil {
+		_ = tx.Rollback()
+		return 0, enterr.Convert(err, nil)
+	}
+	if _, err = tx.RecoveryCode.Delete().Where(recoverycode.UserIDIn(ids...)).Exec(ctx); err != nil {
+		_ = tx.Rollback()
+		return 0, enterr.Convert(err, nil)
+	}
+	n, err := tx.User.Delete().Where(user.IDIn(ids...)).Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, enterr.Convert(err, nil)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, enterr.Convert(err, nil)
+	}
+
+	// 登录凭证在提交后清理，失败时仍会随过期时间失效
+	for _, id := range ids {
+		if err := deleteUserTokens(ctx, r.data.rds, id); err != nil {
+			r.log.WithContext(ctx).Warnf("删除用户id=%d的refresh token失败: %v", id, err)
+		}
+	}
+	return n, nil
 }
 
 func ConvertToUser(u *ent.User) *biz.User {
-	return &biz.User{
+	bu := &biz.User{
 		Id:        u.ID,
 		Username:  &u.Username,
-		Password:  &u.Password,
 		CreatedAt: &u.CreatedAt,
 		UpdatedAt: &u.UpdatedAt,
 	}
-}
-
-func (r *userRepo) getUserCache(ctx context.Context, key string) (*ent.User, error) {
-	cacheUser := &ent.User{}
-	err := r.data.rds.Do(ctx, r.data.rds.B().JsonGet().Key(key).Paths(".").Build()).DecodeJSON(cacheUser)
-	if err != nil {
-		return nil, err
+	// 只有显式查询了密码时才返回
+	if u.Password != "" {
+		bu.Password = &u.Password
+	}
+	if u.Email != "" {
+		bu.Email = &u.Email
+	}
+	if !u.EmailVerifiedAt.IsZero() {
+		bu.EmailVerifiedAt = &u.EmailVerifiedAt
+	}
+	if u.Nickname != "" {
+		bu.Nickname = &u.Nickname
+	}
+	if u.AvatarURL != "" {
+		bu.AvatarUrl = &u.AvatarURL
+	}
+	if u.Phone != "" {
+		bu.Phone = &u.Phone
 	}
-	return cacheUser, nil
+	if u.Locale != "" {
+		bu.Locale = &u.Locale
+	}
+	if u.Timezone != "" {
+		bu.Timezone = &u.Timezone
+	}
+	if u.Bio != "" {
+		bu.Bio = &u.Bio
+	}
+	bu.Attributes = u.Attributes
+	if u.Version != 0 {
+		bu.Version = &u.Version
+	}
+	if u.Status != "" {
+		bu.Status = &u.Status
+	}
+	if u.StatusReason != "" {
+		bu.StatusReason = &u.StatusReason
+	}
+	if u.StatusChangedBy != 0 {
+		bu.StatusChangedBy = &u.StatusChangedBy
+	}
+	if !u.StatusChangedAt.IsZero() {
+		bu.StatusChangedAt = &u.StatusChangedAt
+	}
+	return bu
 }
 
-func (r *userRepo) setUserCache(ctx context.Context, user *ent.User, key string) {
-	val, _ := json.Marshal(user)
-	r.data.rds.Do(ctx, r.data.rds.B().JsonSet().Key(key).Path(".").Value(string(val)).Build())
-	r.data.rds.Do(ctx, r.data.rds.B().Expire().Key(key).Seconds(600).Build())
+// invalidateCache 删除用户缓存，在事务中时延迟到提交之后，避免回滚时缓存被误删
+// 或在提交前被并发读取的旧数据重新填充
+func (r *userRepo) invalidateCache(ctx context.Context, id int64) {
+	r.data.AfterCommit(ctx, func(ctx context.Context) {
+		key := userCacheKey(fmt.Sprintf("%d", id))
+		if err := r.cache.Del(ctx, key); err != nil {
+			r.log.WithContext(ctx).Warnf("删除用户缓存%s失败: %v", key, err)
+		}
+	})
 }
@@ -0,0 +1,60 @@
+package aead
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+)
+
+var ErrCiphertext = errors.New("aead: invalid ciphertext")
+
+// Cipher AES-GCM加密，密文为base64(nonce||ciphertext)
+type Cipher struct {
+	gcm cipher.AEAD
+}
+
+// New key长度须为16、24或32字节
+func New(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{gcm: gcm}, nil
+}
+
+func NewFromBase64(key string) (*Cipher, error) {
+	b, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+	return New(b)
+}
+
+// Seal additionalData不加密，但解密时须一致，用于将密文与其所属对象绑定
+func (c *Cipher) Seal(plaintext, additionalData []byte) (string, error) {
+	nonce := make([]byte, c.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.gcm.Seal(nonce, nonce, plaintext, additionalData)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *Cipher) Open(ciphertext string, additionalData []byte) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(b) < c.gcm.NonceSize() {
+		return nil, ErrCiphertext
+	}
+	nonce, sealed := b[:c.gcm.NonceSize()], b[c.gcm.NonceSize():]
+	plaintext, err := c.gcm.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, ErrCiphertext
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,66 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalid = errors.New("cursor: invalid token")
+
+// Codec 将分页游标编码为base64url(json).base64url(hmac)，客户端无法伪造或篡改
+type Codec struct {
+	key []byte
+}
+
+func New(key []byte) *Codec {
+	return &Codec{key: key}
+}
+
+// NewRandom 使用随机密钥，游标在重启后及不同实例间无法通用
+func NewRandom() (*Codec, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return New(key), nil
+}
+
+func (c *Codec) Encode(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode 签名不一致时返回ErrInvalid
+func (c *Codec) Decode(token string, v interface{}) error {
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, c.sign(payload)) {
+		return ErrInvalid
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return ErrInvalid
+	}
+	if err = json.Unmarshal(b, v); err != nil {
+		return ErrInvalid
+	}
+	return nil
+}
+
+func (c *Codec) sign(payload string) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,106 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCursor struct {
+	CreatedAt time.Time `json:"t"`
+	Id        int64     `json:"id"`
+	Backward  bool      `json:"b,omitempty"`
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	c := New([]byte("test-key"))
+	want := testCursor{CreatedAt: time.Unix(1660000000, 0).UTC(), Id: 42, Backward: true}
+	token, err := c.Encode(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got testCursor
+	if err = c.Decode(token, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.Id != want.Id || got.Backward != want.Backward {
+		t.Errorf("Decode() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCodecRejectsTampering(t *testing.T) {
+	c := New([]byte("test-key"))
+	token, err := c.Encode(testCursor{CreatedAt: time.Unix(1660000000, 0).UTC(), Id: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+
+	// 篡改后的载荷使用合法的编码，只有签名校验能拦截
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2022-08-08T23:06:40Z","id":1}`))
+	other, err := New([]byte("other-key")).Encode(testCursor{Id: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flipped := []byte(sig)
+	if flipped[0] == 'A' {
+		flipped[0] = 'B'
+	} else {
+		flipped[0] = 'A'
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "空字符串", token: ""},
+		{name: "缺少签名", token: payload},
+		{name: "空签名", token: payload + "."},
+		{name: "替换载荷", token: forged + "." + sig},
+		{name: "修改签名", token: payload + "." + string(flipped)},
+		{name: "签名不是base64", token: payload + ".!!!"},
+		{name: "其他密钥签名", token: other},
+		{name: "载荷与签名互换", token: sig + "." + payload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v testCursor
+			if err := c.Decode(tt.token, &v); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Decode(%q) error = %v, want ErrInvalid", tt.token, err)
+			}
+		})
+	}
+}
+
+func TestCodecRejectsSignedGarbage(t *testing.T) {
+	c := New([]byte("test-key"))
+	// 签名正确但载荷不是合法的base64或json
+	for _, payload := range []string{"!!!", base64.RawURLEncoding.EncodeToString([]byte("not json"))} {
+		token := payload + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload))
+		var v testCursor
+		if err := c.Decode(token, &v); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Decode(%q) error = %v, want ErrInvalid", token, err)
+		}
+	}
+}
+
+func TestNewRandomCodecsDiffer(t *testing.T) {
+	a, err := NewRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := a.Encode(testCursor{Id: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v testCursor
+	if err = b.Decode(token, &v); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Decode() with another random key error = %v, want ErrInvalid", err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package enterr 将ent返回的错误统一转换为业务错误，data层各repo方法均通过Convert返回错误
+package enterr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	stderrors "errors"
+	"net"
+	"regexp"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-sql-driver/mysql"
+	"user-service/internal/data/ent"
+	"user-service/internal/data/ent/user"
+	ex "user-service/internal/pkg/errors"
+)
+
+// uniqueKeys 唯一索引与业务错误的对应关系，ent生成的唯一索引与列同名
+var uniqueKeys = map[string]*errors.Error{
+	user.FieldUsernameKey: ex.UsernameTaken,
+	user.FieldEmail:       ex.EmailTaken,
+}
+
+// MySQL 8为 for key 'user.email'，5.7为 for key 'email'
+var duplicateKeyPattern = regexp.MustCompile(`Duplicate entry .* for key '(?:[^'.]+\.)?([^']+)'`)
+
+// 可重试的MySQL错误：连接数过多、锁等待超时、死锁
+var retryableMySQLErrors = map[uint16]bool{
+	1040: true,
+	1205: true,
+	1213: true,
+}
+
+// Convert 将ent错误转换为业务错误，记录不存在时返回notFound，notFound为nil时返回ex.RecordNotFound；
+// 数据库连接失败、超时等可重试的错误返回ex.DatabaseUnavailable，其余错误返回ex.InternalError
+func Convert(err error, notFound *errors.Error) error {
+	switch {
+	case err == nil:
+		return nil
+	case isKratosError(err), stderrors.Is(err, context.Canceled):
+		// 已经是业务错误，或调用方已取消，原样返回
+		return err
+	case ent.IsNotFound(err):
+		if notFound == nil {
+			return ex.RecordNotFound
+		}
+		return notFound
+	case ent.IsConstraintError(err):
+		if e, ok := uniqueKeys[uniqueKey(err)]; ok {
+			return e
+		}
+		return ex.DataConflict
+	case ent.IsValidationError(err):
+		var ve *ent.ValidationError
+		stderrors.As(err, &ve)
+		return ex.InvalidArgument.WithMetadata(map[string]string{ve.Name: ve.Error()})
+	case unavailable(err):
+		return ex.DatabaseUnavailable.WithCause(err)
+	}
+	return ex.InternalError.WithCause(err)
+}
+
+func isKratosError(err error) bool {
+	var se *errors.Error
+	return stderrors.As(err, &se)
+}
+
+func unavailable(err error) bool {
+	if stderrors.Is(err, context.DeadlineExceeded) ||
+		stderrors.Is(err, driver.ErrBadConn) ||
+		stderrors.Is(err, sql.ErrConnDone) ||
+		stderrors.Is(err, mysql.ErrInvalidConn) {
+		return true
+	}
+	var me *mysql.MySQLError
+	if stderrors.As(err, &me) {
+		return retryableMySQLErrors[me.Number]
+	}
+	var ne net.Error
+	return stderrors.As(err, &ne)
+}
+
+// uniqueKey 返回违反的唯一索引名，不是唯一约束错误时返回空字符串
+func uniqueKey(err error) string {
+	if !ent.IsConstraintError(err) {
+		return ""
+	}
+	if m := duplicateKeyPattern.FindStringSubmatch(err.Error()); m != nil {
+		return m[1]
+	}
+	return ""
+}
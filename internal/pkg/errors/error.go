@@ -1,10 +1,53 @@
 package ex
 
 import (
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/lovechung/api-base/api/user"
+	"net/http"
 )
 
 var (
-	UserNotFound = user.ErrorUserNotFound(user.UserServiceErrorReason_USER_IS_FREEZE.String() + "|该用户不存在")
+	UserNotFound = user.ErrorUserNotFound(user.UserServiceErrorReason_USER_NOT_FOUND.String() + "|该用户不存在")
 	UserIsFreeze = user.ErrorUserIsFreeze(user.UserServiceErrorReason_USER_IS_FREEZE.String() + "|该用户已冻结，请联系管理员")
+
+	UserIsDisabled          = errors.Forbidden("USER_IS_DISABLED", "USER_IS_DISABLED|该用户已停用，请联系管理员")
+	UserIsPending           = errors.Forbidden("USER_IS_PENDING", "USER_IS_PENDING|该用户尚未完成验证")
+	InvalidStatusTransition = errors.Conflict("INVALID_STATUS_TRANSITION", "INVALID_STATUS_TRANSITION|当前状态不允许该操作")
+	VersionConflict         = errors.Conflict("VERSION_CONFLICT", "VERSION_CONFLICT|用户信息已被他人修改，请刷新后重试")
+
+	UsernameTaken = errors.Conflict("USERNAME_TAKEN", "USERNAME_TAKEN|该用户名已被占用")
+	WeakPassword  = errors.BadRequest("WEAK_PASSWORD", "WEAK_PASSWORD|密码不符合安全要求")
+
+	EmailTaken               = errors.Conflict("EMAIL_TAKEN", "EMAIL_TAKEN|该邮箱已被占用")
+	EmailNotSet              = errors.BadRequest("EMAIL_NOT_SET", "EMAIL_NOT_SET|尚未设置邮箱")
+	EmailAlreadyVerified     = errors.Conflict("EMAIL_ALREADY_VERIFIED", "EMAIL_ALREADY_VERIFIED|邮箱已验证")
+	VerificationTokenInvalid = errors.BadRequest("VERIFICATION_TOKEN_INVALID", "VERIFICATION_TOKEN_INVALID|验证链接无效或已过期")
+	ResetTokenInvalid        = errors.BadRequest("RESET_TOKEN_INVALID", "RESET_TOKEN_INVALID|重置链接无效或已过期")
+
+	RecordNotFound = errors.NotFound("RECORD_NOT_FOUND", "RECORD_NOT_FOUND|数据不存在")
+	DataConflict   = errors.Conflict("DATA_CONFLICT", "DATA_CONFLICT|数据冲突，请检查后重试")
+
+	DatabaseUnavailable = errors.ServiceUnavailable("DATABASE_UNAVAILABLE", "DATABASE_UNAVAILABLE|服务暂时不可用，请稍后重试")
+	InternalError       = errors.InternalServer("INTERNAL_ERROR", "INTERNAL_ERROR|服务内部错误")
+
+	InvalidArgument  = errors.BadRequest("INVALID_ARGUMENT", "INVALID_ARGUMENT|请求参数不合法")
+	InvalidFieldMask = errors.BadRequest("INVALID_FIELD_MASK", "INVALID_FIELD_MASK|请求的字段不存在或不可见")
+	InvalidPageToken = errors.BadRequest("INVALID_PAGE_TOKEN", "INVALID_PAGE_TOKEN|分页游标无效，请从第一页重新查询")
+
+	PermissionDenied = errors.Forbidden("PERMISSION_DENIED", "PERMISSION_DENIED|没有操作权限")
+	RoleNotFound     = errors.NotFound("ROLE_NOT_FOUND", "ROLE_NOT_FOUND|该角色不存在")
+
+	InvalidCredentials  = errors.Unauthorized("INVALID_CREDENTIALS", "INVALID_CREDENTIALS|用户名或密码错误")
+	Unauthenticated     = errors.Unauthorized("UNAUTHENTICATED", "UNAUTHENTICATED|请先登录")
+	RefreshTokenInvalid = errors.Unauthorized("REFRESH_TOKEN_INVALID", "REFRESH_TOKEN_INVALID|refresh token无效")
+	RefreshTokenExpired = errors.Unauthorized("REFRESH_TOKEN_EXPIRED", "REFRESH_TOKEN_EXPIRED|refresh token已过期，请重新登录")
+	RefreshTokenReused  = errors.Unauthorized("REFRESH_TOKEN_REUSED", "REFRESH_TOKEN_REUSED|refresh token已被使用，请重新登录")
+
+	MfaNotConfigured   = errors.ServiceUnavailable("MFA_NOT_CONFIGURED", "MFA_NOT_CONFIGURED|两步验证暂不可用")
+	TotpNotEnrolled    = errors.BadRequest("TOTP_NOT_ENROLLED", "TOTP_NOT_ENROLLED|尚未绑定两步验证")
+	TotpAlreadyEnabled = errors.Conflict("TOTP_ALREADY_ENABLED", "TOTP_ALREADY_ENABLED|已启用两步验证")
+	InvalidMfaCode     = errors.Unauthorized("INVALID_MFA_CODE", "INVALID_MFA_CODE|验证码错误")
+	MfaTokenInvalid    = errors.Unauthorized("MFA_TOKEN_INVALID", "MFA_TOKEN_INVALID|验证已失效，请重新登录")
+
+	AccountLocked = errors.New(http.StatusTooManyRequests, "ACCOUNT_LOCKED", "ACCOUNT_LOCKED|登录失败次数过多，请稍后再试")
 )
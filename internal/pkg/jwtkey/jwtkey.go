@@ -0,0 +1,126 @@
+package jwtkey
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	jwtV4 "github.com/golang-jwt/jwt/v4"
+	"user-service/internal/conf"
+)
+
+var (
+	ErrNoSigningKey = errors.New("jwt signing key is not configured")
+	ErrUnknownKid   = errors.New("jwt kid is unknown")
+)
+
+type Key struct {
+	Kid       string
+	SignKey   interface{}
+	VerifyKey interface{}
+}
+
+// KeySet 持有jwt签名及校验所需的密钥，以kid区分
+// 轮换密钥时先加入新key并切换signing_kid，旧key保留到其签发的token全部过期后再移除
+type KeySet struct {
+	method  jwtV4.SigningMethod
+	signing *Key
+	keys    map[string]*Key
+}
+
+func New(c *conf.Auth_Jwt) (*KeySet, error) {
+	if c == nil {
+		return nil, ErrNoSigningKey
+	}
+	alg := c.Algorithm
+	if alg == "" {
+		alg = jwtV4.SigningMethodHS256.Alg()
+	}
+	method := jwtV4.GetSigningMethod(alg)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported jwt algorithm: %s", alg)
+	}
+
+	s := &KeySet{method: method, keys: make(map[string]*Key)}
+	for _, kc := range c.Keys {
+		k, err := parseKey(method, kc)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", kc.Kid, err)
+		}
+		s.keys[k.Kid] = k
+	}
+
+	signing, ok := s.keys[c.SigningKid]
+	if !ok || signing.SignKey == nil {
+		return nil, ErrNoSigningKey
+	}
+	s.signing = signing
+	return s, nil
+}
+
+func parseKey(method jwtV4.SigningMethod, c *conf.Auth_Jwt_Key) (*Key, error) {
+	k := &Key{Kid: c.Kid}
+	switch method.(type) {
+	case *jwtV4.SigningMethodHMAC:
+		if c.Secret == "" {
+			return nil, errors.New("secret is empty")
+		}
+		k.SignKey = []byte(c.Secret)
+		k.VerifyKey = k.SignKey
+	case *jwtV4.SigningMethodRSA:
+		if c.PrivateKey != "" {
+			pk, err := jwtV4.ParseRSAPrivateKeyFromPEM([]byte(c.PrivateKey))
+			if err != nil {
+				return nil, err
+			}
+			k.SignKey, k.VerifyKey = pk, &pk.PublicKey
+		} else {
+			pub, err := jwtV4.ParseRSAPublicKeyFromPEM([]byte(c.PublicKey))
+			if err != nil {
+				return nil, err
+			}
+			k.VerifyKey = pub
+		}
+	case *jwtV4.SigningMethodEd25519:
+		if c.PrivateKey != "" {
+			pk, err := jwtV4.ParseEdPrivateKeyFromPEM([]byte(c.PrivateKey))
+			if err != nil {
+				return nil, err
+			}
+			k.SignKey, k.VerifyKey = pk, pk.(ed25519.PrivateKey).Public()
+		} else {
+			pub, err := jwtV4.ParseEdPublicKeyFromPEM([]byte(c.PublicKey))
+			if err != nil {
+				return nil, err
+			}
+			k.VerifyKey = pub
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm: %s", method.Alg())
+	}
+	return k, nil
+}
+
+func (s *KeySet) Method() jwtV4.SigningMethod {
+	return s.method
+}
+
+// Sign 使用当前签名密钥签发token，并在头部写入kid
+func (s *KeySet) Sign(claims jwtV4.Claims) (string, error) {
+	token := jwtV4.NewWithClaims(s.method, claims)
+	token.Header["kid"] = s.signing.Kid
+	return token.SignedString(s.signing.SignKey)
+}
+
+// Keyfunc 按token头部的kid查找校验密钥，所有已配置的key均可用于校验
+func (s *KeySet) Keyfunc(token *jwtV4.Token) (interface{}, error) {
+	if token.Method.Alg() != s.method.Alg() {
+		return nil, fmt.Errorf("unexpected jwt algorithm: %s", token.Method.Alg())
+	}
+	kid, _ := token.Header["kid"].(string)
+	k, ok := s.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKid
+	}
+	return k.VerifyKey, nil
+}
@@ -0,0 +1,130 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// RFC 6238附录B的SHA-1测试密钥"12345678901234567890"
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+// RFC 6238附录B的SHA-1测试向量，8位验证码取后6位
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCodeRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		got, err := Code(rfcSecret, Step(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code(%d): %v", v.unix, err)
+		}
+		if got != v.code {
+			t.Errorf("Code(%d) = %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestCodeAcceptsLowercaseSecret(t *testing.T) {
+	upper, err := Code(rfcSecret, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lower, err := Code("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upper != lower {
+		t.Errorf("lowercase secret code = %s, want %s", lower, upper)
+	}
+}
+
+func TestCodeInvalidSecret(t *testing.T) {
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error("Code() error = nil, want decode error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	code := func(s int64) string {
+		c, err := Code(rfcSecret, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		skew     int
+		wantStep int64
+		wantOk   bool
+	}{
+		{name: "当前时间步", code: code(step), skew: 0, wantStep: step, wantOk: true},
+		{name: "前一个时间步在偏差内", code: code(step - 1), skew: 1, wantStep: step - 1, wantOk: true},
+		{name: "后一个时间步在偏差内", code: code(step + 1), skew: 1, wantStep: step + 1, wantOk: true},
+		{name: "前一个时间步超出偏差", code: code(step - 1), skew: 0},
+		{name: "超出偏差两个时间步", code: code(step + 2), skew: 1},
+		{name: "错误的验证码", code: "000000", skew: 1},
+		{name: "位数不足", code: code(step)[:Digits-1], skew: 1},
+		{name: "位数过多", code: code(step) + "0", skew: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := Validate(rfcSecret, tt.code, now, tt.skew)
+			if ok != tt.wantOk {
+				t.Fatalf("Validate() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && gotStep != tt.wantStep {
+				t.Errorf("Validate() step = %d, want %d", gotStep, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := encoding.DecodeString(a)
+	if err != nil {
+		t.Fatalf("secret %q is not base32: %v", a, err)
+	}
+	if len(key) != SecretSize {
+		t.Errorf("secret size = %d, want %d", len(key), SecretSize)
+	}
+	b, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Error("GenerateSecret() returned the same secret twice")
+	}
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("user-service", "alice", rfcSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/user-service:alice" {
+		t.Errorf("URI() = %s", u)
+	}
+	q := u.Query()
+	if q.Get("secret") != rfcSecret || q.Get("issuer") != "user-service" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("URI() query = %v", q)
+	}
+}
@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	ex "user-service/internal/pkg/errors"
+)
+
+// Rule 校验请求参数，将不合法的字段记录到v中
+type Rule func(req interface{}, v *Violations)
+
+// Violations 收集字段级别的校验错误
+type Violations struct {
+	list []*errdetails.BadRequest_FieldViolation
+}
+
+func (v *Violations) Add(field, format string, args ...interface{}) {
+	v.list = append(v.list, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// Check 条件不成立时记录一条校验错误
+func (v *Violations) Check(ok bool, field, format string, args ...interface{}) {
+	if !ok {
+		v.Add(field, format, args...)
+	}
+}
+
+func (v *Violations) Empty() bool {
+	return len(v.list) == 0
+}
+
+// Err 没有校验错误时返回nil
+func (v *Violations) Err() error {
+	return v.ErrAs(ex.InvalidArgument)
+}
+
+// ErrAs 以指定的错误原因返回校验错误，没有校验错误时返回nil
+func (v *Violations) ErrAs(base *errors.Error) error {
+	if v.Empty() {
+		return nil
+	}
+	md := make(map[string]string, len(v.list))
+	for _, fv := range v.list {
+		// 同一字段只保留第一条
+		if _, ok := md[fv.Field]; !ok {
+			md[fv.Field] = fv.Description
+		}
+	}
+	return &Error{
+		err:        base.WithMetadata(md),
+		violations: v.list,
+	}
+}
+
+// Error 在kratos错误的ErrorInfo之外，附带google.rpc.BadRequest详情
+type Error struct {
+	err        *errors.Error
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 使errors.FromError等仍能取到kratos错误
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Violations() []*errdetails.BadRequest_FieldViolation {
+	return e.violations
+}
+
+func (e *Error) GRPCStatus() *status.Status {
+	s := e.err.GRPCStatus()
+	if ds, err := s.WithDetails(&errdetails.BadRequest{FieldViolations: e.violations}); err == nil {
+		return ds
+	}
+	return s
+}
+
+type validator interface {
+	Validate() error
+}
+
+// Server 请求参数校验中间件，兼容实现了Validate方法的请求（如protoc-gen-validate生成的代码）
+func Server(rule Rule) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if v, ok := req.(validator); ok {
+				if err := v.Validate(); err != nil {
+					return nil, ex.InvalidArgument.WithCause(err)
+				}
+			}
+			v := &Violations{}
+			rule(req, v)
+			if err := v.Err(); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	jwtV4 "github.com/golang-jwt/jwt/v4"
+	"github.com/lovechung/api-base/api/user"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"user-service/internal/biz"
+	"user-service/internal/conf"
+	"user-service/internal/pkg/jwtkey"
+)
+
+// 无需登录即可访问的接口
+var publicOperations = map[string]struct{}{
+	userOperation("Login"):                {},
+	userOperation("RefreshToken"):         {},
+	userOperation("Logout"):               {},
+	userOperation("SaveUser"):             {},
+	userOperation("IsUsernameAvailable"):  {},
+	userOperation("VerifyMfa"):            {},
+	userOperation("VerifyEmail"):          {},
+	userOperation("RequestPasswordReset"): {},
+	userOperation("ResetPassword"):        {},
+}
+
+// 供其他服务调用的查询接口，携带正确的服务密钥时无需用户登录
+var serviceOperations = map[string]struct{}{
+	userOperation("GetUser"):        {},
+	userOperation("ListUser"):       {},
+	userOperation("GetUserName"):    {},
+	userOperation("GetUserNameMap"): {},
+}
+
+// 服务间调用通过该请求头传递conf.Auth.service_key
+const serviceKeyHeader = "x-service-key"
+
+func userOperation(method string) string {
+	return "/" + user.User_ServiceDesc.ServiceName + "/" + method
+}
+
+func NewWhiteListMatcher(serviceKey string) selector.MatchFunc {
+	healthPrefix := "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/"
+	return func(ctx context.Context, operation string) bool {
+		if strings.HasPrefix(operation, healthPrefix) {
+			return false
+		}
+		if _, ok := publicOperations[operation]; ok {
+			return false
+		}
+		if _, ok := serviceOperations[operation]; ok && isServiceCall(ctx, serviceKey) {
+			return false
+		}
+		return true
+	}
+}
+
+// isServiceCall 未配置服务密钥时不接受服务间调用
+func isServiceCall(ctx context.Context, serviceKey string) bool {
+	if serviceKey == "" {
+		return false
+	}
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return false
+	}
+	key := tr.RequestHeader().Get(serviceKeyHeader)
+	return subtle.ConstantTimeCompare([]byte(key), []byte(serviceKey)) == 1
+}
+
+// NewAuthMiddleware 校验access token，并将调用者以biz.Principal的形式放入上下文
+func NewAuthMiddleware(c *conf.Auth, keys *jwtkey.KeySet) middleware.Middleware {
+	return selector.Server(
+		jwt.Server(
+			keys.Keyfunc,
+			jwt.WithSigningMethod(keys.Method()),
+			jwt.WithClaims(func() jwtV4.Claims { return &biz.Claims{} }),
+		),
+		principal(c.GetJwt()),
+	).Match(NewWhiteListMatcher(c.GetServiceKey())).Build()
+}
+
+func principal(c *conf.Auth_Jwt) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tokenClaims, ok := jwt.FromContext(ctx)
+			if !ok {
+				return nil, jwt.ErrMissingJwtToken
+			}
+			claims, ok := tokenClaims.(*biz.Claims)
+			if !ok || claims.TokenType != biz.TokenTypeAccess {
+				return nil, jwt.ErrTokenInvalid
+			}
+			if c.GetIssuer() != "" && !claims.VerifyIssuer(c.GetIssuer(), true) {
+				return nil, jwt.ErrTokenInvalid
+			}
+			for _, aud := range c.GetAudience() {
+				if !claims.VerifyAudience(aud, true) {
+					return nil, jwt.ErrTokenInvalid
+				}
+			}
+			userId, err := strconv.ParseInt(claims.Subject, 10, 64)
+			if err != nil {
+				return nil, jwt.ErrTokenInvalid
+			}
+
+			ctx = biz.NewPrincipalContext(ctx, &biz.Principal{
+				UserId:   userId,
+				Username: claims.Username,
+				TokenId:  claims.ID,
+			})
+			return handler(ctx, req)
+		}
+	}
+}
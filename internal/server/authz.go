@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"user-service/internal/biz"
+)
+
+type requirement struct {
+	permission string
+	// 允许调用者不具备权限时操作自己的数据
+	allowSelf bool
+}
+
+// 各接口所需的权限，未列出的接口只需登录
+var operationRequirements = map[string]requirement{
+	userOperation("UpdateUser"):   {permission: biz.PermissionUserUpdate, allowSelf: true},
+	userOperation("DeleteUser"):   {permission: biz.PermissionUserDelete},
+	userOperation("RestoreUser"):  {permission: biz.PermissionUserDelete},
+	userOperation("GrantRole"):    {permission: biz.PermissionRoleGrant},
+	userOperation("RevokeRole"):   {permission: biz.PermissionRoleGrant},
+	userOperation("FreezeUser"):   {permission: biz.PermissionUserFreeze},
+	userOperation("UnfreezeUser"): {permission: biz.PermissionUserFreeze},
+	userOperation("DisableUser"):  {permission: biz.PermissionUserFreeze},
+	userOperation("ClearLockout"): {permission: biz.PermissionUserUnlock},
+}
+
+// NewAuthzMiddleware 按接口校验调用者的权限，需位于鉴权中间件之后
+func NewAuthzMiddleware(a *biz.Authorizer) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			rule, ok := operationRequirements[tr.Operation()]
+			if !ok {
+				return handler(ctx, req)
+			}
+			if rule.allowSelf && isSelf(ctx, req) {
+				return handler(ctx, req)
+			}
+			if err := a.Authorize(ctx, rule.permission); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+func isSelf(ctx context.Context, req interface{}) bool {
+	p, ok := biz.PrincipalFromContext(ctx)
+	if !ok {
+		return false
+	}
+	r, ok := req.(interface{ GetId() int64 })
+	return ok && r.GetId() == p.UserId
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/biz"
+)
+
+// BackfillServer 启动时回填历史用户的规范化用户名，完成后即退出，停止服务时中断
+type BackfillServer struct {
+	uc   *biz.UserUseCase
+	quit chan struct{}
+	log  *log.Helper
+}
+
+func NewBackfillServer(uc *biz.UserUseCase, logger log.Logger) *BackfillServer {
+	return &BackfillServer{
+		uc:   uc,
+		quit: make(chan struct{}),
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (s *BackfillServer) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := s.uc.BackfillUsernameKeys(ctx); err != nil && ctx.Err() == nil {
+		s.log.Errorf("回填用户名唯一键失败: %v", err)
+	}
+	return nil
+}
+
+func (s *BackfillServer) Stop(_ context.Context) error {
+	close(s.quit)
+	return nil
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/biz"
+	"user-service/internal/conf"
+)
+
+const defaultBloomRebuildInterval = 24 * time.Hour
+
+// IdFilterServer 启动时构建尚不存在的用户id过滤器，之后定期重建，未开启布隆过滤器时不启用
+type IdFilterServer struct {
+	uc       *biz.UserUseCase
+	enabled  bool
+	interval time.Duration
+	quit     chan struct{}
+	log      *log.Helper
+}
+
+func NewIdFilterServer(c *conf.Data, uc *biz.UserUseCase, logger log.Logger) *IdFilterServer {
+	s := &IdFilterServer{
+		uc:       uc,
+		enabled:  c.GetCache().GetBloom().GetEnabled(),
+		interval: defaultBloomRebuildInterval,
+		quit:     make(chan struct{}),
+		log:      log.NewHelper(logger),
+	}
+	if d := c.GetCache().GetBloom().GetRebuildInterval(); d != nil {
+		s.interval = d.AsDuration()
+	}
+	return s
+}
+
+func (s *IdFilterServer) Start(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+	// 停止时中断正在进行的构建
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := s.uc.RebuildIdFilter(ctx, false); err != nil && ctx.Err() == nil {
+		s.log.Errorf("构建用户id过滤器失败: %v", err)
+	}
+	if s.interval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.uc.RebuildIdFilter(ctx, true); err != nil && ctx.Err() == nil {
+				s.log.Errorf("重建用户id过滤器失败: %v", err)
+			}
+		}
+	}
+}
+
+func (s *IdFilterServer) Stop(_ context.Context) error {
+	close(s.quit)
+	return nil
+}
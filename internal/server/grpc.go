@@ -3,7 +3,6 @@ package server
 import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
-	"github.com/go-kratos/kratos/v2/middleware/logging"
 	"github.com/go-kratos/kratos/v2/middleware/ratelimit"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
@@ -14,12 +13,15 @@ import (
 	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/metric/unit"
+	"user-service/internal/biz"
 	"user-service/internal/conf"
+	"user-service/internal/pkg/jwtkey"
+	"user-service/internal/pkg/validate"
 	"user-service/internal/service"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, us *service.UserService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, ac *conf.Auth, keys *jwtkey.KeySet, authorizer *biz.Authorizer, us *service.UserService, logger log.Logger) *grpc.Server {
 	meter := global.Meter("user-service")
 	requestHistogram, _ := meter.SyncInt64().Histogram("user_service_req", instrument.WithUnit(unit.Milliseconds))
 
@@ -32,10 +34,10 @@ func NewGRPCServer(c *conf.Server, us *service.UserService, logger log.Logger) *
 				metrics.Server(
 					metrics.WithSeconds(contrib.NewHistogram(requestHistogram)),
 				),
-				//jwt.Server(func(token *jwtV4.Token) (interface{}, error) {
-				//	return []byte("123456"), nil
-				//}),
-				logging.Server(logger),
+				NewAuthMiddleware(ac, keys),
+				NewAuthzMiddleware(authorizer),
+				NewLoggingMiddleware(logger),
+				validate.Server(service.ValidateRequest),
 			),
 		),
 	}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/logging"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
+)
+
+// 请求参数包含密码、验证码或令牌的接口，日志中不记录参数
+var sensitiveOperations = map[string]struct{}{
+	userOperation("Login"):         {},
+	userOperation("RefreshToken"):  {},
+	userOperation("Logout"):        {},
+	userOperation("VerifyMfa"):     {},
+	userOperation("ConfirmTOTP"):   {},
+	userOperation("VerifyEmail"):   {},
+	userOperation("ResetPassword"): {},
+	userOperation("SaveUser"):      {},
+	userOperation("UpdateUser"):    {},
+}
+
+type redactedArgs struct{}
+
+func (redactedArgs) String() string {
+	return "[REDACTED]"
+}
+
+// NewLoggingMiddleware 记录请求日志，敏感接口的参数以占位符代替
+func NewLoggingMiddleware(logger log.Logger) middleware.Middleware {
+	return middleware.Chain(
+		selector.Server(logging.Server(logger)).Match(func(_ context.Context, operation string) bool {
+			_, ok := sensitiveOperations[operation]
+			return !ok
+		}).Build(),
+		selector.Server(redactedLogging(logger)).Match(func(_ context.Context, operation string) bool {
+			_, ok := sensitiveOperations[operation]
+			return ok
+		}).Build(),
+	)
+}
+
+// redactedLogging 交给logging的是占位参数，实际请求绕过日志直接传给下一层
+func redactedLogging(logger log.Logger) middleware.Middleware {
+	logged := logging.Server(logger)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return logged(func(ctx context.Context, _ interface{}) (interface{}, error) {
+				return handler(ctx, req)
+			})(ctx, redactedArgs{})
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"user-service/internal/biz"
+	"user-service/internal/conf"
+)
+
+const (
+	defaultPurgeInterval  = time.Hour
+	defaultPurgeBatchSize = 500
+)
+
+// PurgeServer 定时物理删除超过保留期的已软删除用户，未配置保留期时不启用
+type PurgeServer struct {
+	uc        *biz.UserUseCase
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+	quit      chan struct{}
+	log       *log.Helper
+}
+
+func NewPurgeServer(c *conf.Data, uc *biz.UserUseCase, logger log.Logger) *PurgeServer {
+	s := &PurgeServer{
+		uc:        uc,
+		interval:  defaultPurgeInterval,
+		batchSize: defaultPurgeBatchSize,
+		quit:      make(chan struct{}),
+		log:       log.NewHelper(logger),
+	}
+	if d := c.GetPurge().GetRetention(); d != nil {
+		s.retention = d.AsDuration()
+	}
+	if d := c.GetPurge().GetInterval(); d != nil && d.AsDuration() > 0 {
+		s.interval = d.AsDuration()
+	}
+	if n := c.GetPurge().GetBatchSize(); n > 0 {
+		s.batchSize = int(n)
+	}
+	return s
+}
+
+func (s *PurgeServer) Start(ctx context.Context) error {
+	if s.retention <= 0 {
+		s.log.Info("未配置软删除保留期，不启用清理任务")
+		return nil
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return nil
+		case <-ticker.C:
+			n, err := s.uc.PurgeDeletedUsers(ctx, s.retention, s.batchSize)
+			if err != nil {
+				s.log.Errorf("清理已删除用户失败: %v", err)
+			}
+			if n > 0 {
+				s.log.Infof("本次清理已删除用户%d个", n)
+			}
+		}
+	}
+}
+
+func (s *PurgeServer) Stop(_ context.Context) error {
+	close(s.quit)
+	return nil
+}
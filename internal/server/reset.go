@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"user-service/internal/biz"
+)
+
+// 并发处理重置密码请求的数量，限制同时进行的SMTP连接
+const resetWorkers = 4
+
+// PasswordResetServer 在后台处理排队的重置密码请求，停止时处理完已排队的请求
+type PasswordResetServer struct {
+	rc   *biz.PasswordResetUseCase
+	quit chan struct{}
+	done chan struct{}
+}
+
+func NewPasswordResetServer(rc *biz.PasswordResetUseCase) *PasswordResetServer {
+	return &PasswordResetServer{
+		rc:   rc,
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+func (s *PasswordResetServer) Start(_ context.Context) error {
+	defer close(s.done)
+	var wg sync.WaitGroup
+	for i := 0; i < resetWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.rc.Serve(s.quit)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// Stop 等待已排队的请求处理完毕，超出ctx的期限时不再等待
+func (s *PasswordResetServer) Stop(ctx context.Context) error {
+	close(s.quit)
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc/peer"
+	"user-service/internal/conf"
+)
+
+// IPResolver 取调用方的来源IP，只信任配置的代理透传的x-forwarded-for
+type IPResolver struct {
+	trusted []*net.IPNet
+}
+
+func NewIPResolver(c *conf.Server) (*IPResolver, error) {
+	r := &IPResolver{}
+	for _, s := range c.GetTrustedProxies() {
+		if !strings.Contains(s, "/") {
+			if ip := net.ParseIP(s); ip != nil && ip.To4() != nil {
+				s += "/32"
+			} else {
+				s += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("可信代理地址%s格式错误: %w", s, err)
+		}
+		r.trusted = append(r.trusted, n)
+	}
+	return r, nil
+}
+
+// ClientIP 连接来自可信代理时，从x-forwarded-for右侧开始跳过可信代理，取第一个不可信的地址；
+// 否则使用连接的对端地址，客户端自行携带的请求头不可信
+func (r *IPResolver) ClientIP(ctx context.Context) string {
+	remote := peerIP(ctx)
+	if !r.isTrusted(remote) {
+		return remote
+	}
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		if xff := tr.RequestHeader().Get("x-forwarded-for"); xff != "" {
+			hops := strings.Split(xff, ",")
+			for i := len(hops) - 1; i >= 0; i-- {
+				ip := strings.TrimSpace(hops[i])
+				if net.ParseIP(ip) == nil {
+					// 无法解析的地址之前的内容均不可信
+					break
+				}
+				if !r.isTrusted(ip) {
+					return ip
+				}
+			}
+		} else if ip := tr.RequestHeader().Get("x-real-ip"); net.ParseIP(ip) != nil {
+			return ip
+		}
+	}
+	return remote
+}
+
+func (r *IPResolver) isTrusted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range r.trusted {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+func peerIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
@@ -3,11 +3,11 @@ package service
 import (
 	"context"
 	"github.com/go-kratos/kratos/v2/log"
-	jwtV4 "github.com/golang-jwt/jwt/v4"
 	v1 "github.com/lovechung/api-base/api/user"
 	"github.com/lovechung/go-kit/util/pagination"
 	"github.com/lovechung/go-kit/util/time"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"user-service/internal/biz"
 )
@@ -16,21 +16,44 @@ type UserService struct {
 	v1.UnimplementedUserServer
 
 	uc  *biz.UserUseCase
+	ac  *biz.AuthUseCase
+	rc  *biz.RoleUseCase
+	tc  *biz.TotpUseCase
+	ec  *biz.EmailUseCase
+	pc  *biz.PasswordResetUseCase
+	ips *IPResolver
 	log *log.Helper
 }
 
-func NewUserService(uc *biz.UserUseCase, logger log.Logger) *UserService {
-	return &UserService{uc: uc, log: log.NewHelper(logger)}
+func NewUserService(uc *biz.UserUseCase, ac *biz.AuthUseCase, rc *biz.RoleUseCase, tc *biz.TotpUseCase, ec *biz.EmailUseCase, pc *biz.PasswordResetUseCase, ips *IPResolver, logger log.Logger) *UserService {
+	return &UserService{uc: uc, ac: ac, rc: rc, tc: tc, ec: ec, pc: pc, ips: ips, log: log.NewHelper(logger)}
 }
 
 func (s *UserService) ListUser(ctx context.Context, req *v1.ListUserReq) (*v1.ListUserReply, error) {
+	fields, err := biz.ParseUserFields(req.FieldMask.GetPaths())
+	if err != nil {
+		return nil, err
+	}
 	page, pageSize := pagination.GetPage(req.Page, req.PageSize)
-	list, total, err := s.uc.ListUser(ctx, page, pageSize, req.Username)
+	result, err := s.uc.ListUser(ctx, &biz.UserListQuery{
+		Page:      page,
+		PageSize:  pageSize,
+		Username:  req.Username,
+		Fields:    fields,
+		SkipCount: req.SkipCount,
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	rsp := &v1.ListUserReply{}
-	rsp.Total = int32(total)
-	for _, user := range list {
-		userInfo := ConvertToUserReply(user)
+	rsp.Total = int32(result.Total)
+	rsp.HasMore = result.HasMore
+	rsp.NextPageToken = result.NextPageToken
+	rsp.PrevPageToken = result.PrevPageToken
+	for _, user := range result.List {
+		userInfo := ConvertToUserReply(user, fields)
 		rsp.List = append(rsp.List, userInfo)
 	}
 
@@ -40,35 +63,77 @@ func (s *UserService) ListUser(ctx context.Context, req *v1.ListUserReq) (*v1.Li
 	//s.log.WithContext(ctx).Infof("此次慢查询耗时: %ds", spent)
 	//time.Sleep(time.Second * time.Duration(spent))
 
-	return rsp, err
+	return rsp, nil
 }
 
-func (s *UserService) GetUser(ctx context.Context, req *wrapperspb.Int64Value) (*v1.UserReply, error) {
+func (s *UserService) GetUser(ctx context.Context, req *v1.GetUserReq) (*v1.UserReply, error) {
 
 	// 打印一条trace日志
 	s.log.WithContext(ctx).Infof("我是一条【%s】trace日志噢", "info")
 
-	claims := jwtV4.NewWithClaims(jwtV4.SigningMethodHS256,
-		jwtV4.MapClaims{
-			"userId": 123,
-		})
-	token, _ := claims.SignedString([]byte("123456"))
-	s.log.WithContext(ctx).Infof("token=%s", token)
-
-	user, err := s.uc.GetUserById(ctx, req.Value)
+	fields, err := biz.ParseUserFields(req.FieldMask.GetPaths())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.uc.GetUserById(ctx, req.Id, fields)
 	if err != nil {
 		return nil, err
 	}
-	return ConvertToUserReply(user), err
+	return ConvertToUserReply(user, fields), err
+}
+
+// ConvertToUserReply 只返回请求的字段，敏感字段始终不返回
+func ConvertToUserReply(u *biz.User, fields []string) *v1.UserReply {
+	rsp := &v1.UserReply{Id: u.Id}
+	for _, f := range fields {
+		switch f {
+		case biz.UserFieldUsername:
+			rsp.Username = *u.Username
+		case biz.UserFieldCreatedAt:
+			rsp.CreatedAt = t.Format(*u.CreatedAt)
+		case biz.UserFieldUpdatedAt:
+			rsp.UpdatedAt = t.Format(*u.UpdatedAt)
+		case biz.UserFieldEmail:
+			if u.Email != nil {
+				rsp.Email = *u.Email
+			}
+		case biz.UserFieldEmailVerified:
+			rsp.EmailVerified = u.EmailVerifiedAt != nil
+		case biz.UserFieldStatus:
+			if u.Status != nil {
+				rsp.Status = string(*u.Status)
+			}
+		case biz.UserFieldNickname:
+			rsp.Nickname = stringValue(u.Nickname)
+		case biz.UserFieldAvatarUrl:
+			rsp.AvatarUrl = stringValue(u.AvatarUrl)
+		case biz.UserFieldPhone:
+			rsp.Phone = stringValue(u.Phone)
+		case biz.UserFieldLocale:
+			rsp.Locale = stringValue(u.Locale)
+		case biz.UserFieldTimezone:
+			rsp.Timezone = stringValue(u.Timezone)
+		case biz.UserFieldBio:
+			rsp.Bio = stringValue(u.Bio)
+		case biz.UserFieldAttributes:
+			if u.Attributes != nil {
+				// 属性由UpdateUser从Struct转换而来，总能转换回去
+				rsp.Attributes, _ = structpb.NewStruct(u.Attributes)
+			}
+		case biz.UserFieldVersion:
+			if u.Version != nil {
+				rsp.Version = *u.Version
+			}
+		}
+	}
+	return rsp
 }
 
-func ConvertToUserReply(u *biz.User) *v1.UserReply {
-	return &v1.UserReply{
-		Id:        u.Id,
-		Username:  *u.Username,
-		Password:  *u.Password,
-		CreatedAt: t.Format(*u.CreatedAt),
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
 	}
+	return *s
 }
 
 func (s *UserService) GetUserName(ctx context.Context, req *wrapperspb.Int64Value) (*wrapperspb.StringValue, error) {
@@ -81,20 +146,51 @@ func (s *UserService) GetUserNameMap(ctx context.Context, req *v1.UserIdsReq) (*
 	return &v1.UserNameMapReply{NameMap: nameMap}, err
 }
 
+func (s *UserService) IsUsernameAvailable(ctx context.Context, req *wrapperspb.StringValue) (*wrapperspb.BoolValue, error) {
+	ok, err := s.uc.IsUsernameAvailable(ctx, req.Value)
+	return &wrapperspb.BoolValue{Value: ok}, err
+}
+
 func (s *UserService) SaveUser(ctx context.Context, req *v1.SaveUserReq) (*emptypb.Empty, error) {
-	err := s.uc.SaveUser(ctx, &biz.User{
+	id, err := s.uc.SaveUser(ctx, &biz.User{
 		Username: &req.Username,
 		Password: &req.Password,
+		Email:    req.Email,
 	})
-	return &emptypb.Empty{}, err
+	if err != nil {
+		return nil, err
+	}
+	// 填写了邮箱的账号需验证后才能登录，发送失败时登录会再次发送
+	if req.Email != nil {
+		if err = s.ec.SendVerification(ctx, id); err != nil {
+			s.log.WithContext(ctx).Warnf("发送用户id=%d的验证邮件失败: %v", id, err)
+		}
+	}
+	return &emptypb.Empty{}, nil
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, req *v1.UpdateUserReq) (*emptypb.Empty, error) {
-	err := s.uc.UpdateUser(ctx, &biz.User{
-		Id:       req.Id,
-		Username: req.Username,
-		Password: req.Password,
-	})
+	paths, err := biz.ParseUserUpdateMask(req.UpdateMask.GetPaths())
+	if err != nil {
+		return nil, err
+	}
+	u := &biz.User{
+		Id:        req.Id,
+		Username:  req.Username,
+		Password:  req.Password,
+		Email:     req.Email,
+		Nickname:  req.Nickname,
+		AvatarUrl: req.AvatarUrl,
+		Phone:     req.Phone,
+		Locale:    req.Locale,
+		Timezone:  req.Timezone,
+		Bio:       req.Bio,
+		Version:   req.Version,
+	}
+	if req.Attributes != nil {
+		u.Attributes = req.Attributes.AsMap()
+	}
+	err = s.uc.UpdateUser(ctx, u, paths)
 	return &emptypb.Empty{}, err
 }
 
@@ -102,3 +198,125 @@ func (s *UserService) DeleteUser(ctx context.Context, req *wrapperspb.Int64Value
 	err := s.uc.DeleteUser(ctx, req.Value)
 	return &emptypb.Empty{}, err
 }
+
+func (s *UserService) RequestEmailVerification(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	err := s.ec.RequestVerification(ctx)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) VerifyEmail(ctx context.Context, req *v1.VerifyEmailReq) (*emptypb.Empty, error) {
+	err := s.ec.Verify(ctx, req.Token)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) RequestPasswordReset(ctx context.Context, req *v1.RequestPasswordResetReq) (*emptypb.Empty, error) {
+	err := s.pc.Request(ctx, req.Account)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) ResetPassword(ctx context.Context, req *v1.ResetPasswordReq) (*emptypb.Empty, error) {
+	err := s.pc.Reset(ctx, req.Token, req.NewPassword)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) RestoreUser(ctx context.Context, req *wrapperspb.Int64Value) (*emptypb.Empty, error) {
+	err := s.uc.RestoreUser(ctx, req.Value)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) FreezeUser(ctx context.Context, req *v1.ChangeUserStatusReq) (*emptypb.Empty, error) {
+	err := s.uc.ChangeStatus(ctx, req.Id, biz.UserStatusFrozen, req.Reason)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) UnfreezeUser(ctx context.Context, req *v1.ChangeUserStatusReq) (*emptypb.Empty, error) {
+	err := s.uc.ChangeStatus(ctx, req.Id, biz.UserStatusActive, req.Reason)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) DisableUser(ctx context.Context, req *v1.ChangeUserStatusReq) (*emptypb.Empty, error) {
+	err := s.uc.ChangeStatus(ctx, req.Id, biz.UserStatusDisabled, req.Reason)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) Login(ctx context.Context, req *v1.LoginReq) (*v1.LoginReply, error) {
+	token, err := s.ac.Login(ctx, req.Username, req.Password, s.ips.ClientIP(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return ConvertToLoginReply(token), nil
+}
+
+func (s *UserService) ClearLockout(ctx context.Context, req *v1.ClearLockoutReq) (*emptypb.Empty, error) {
+	err := s.ac.ClearLockout(ctx, req.UserId, req.Ip)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) VerifyMfa(ctx context.Context, req *v1.VerifyMfaReq) (*v1.LoginReply, error) {
+	token, err := s.ac.VerifyMfa(ctx, req.MfaToken, req.Code, s.ips.ClientIP(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return ConvertToLoginReply(token), nil
+}
+
+func (s *UserService) EnrollTOTP(ctx context.Context, _ *emptypb.Empty) (*v1.EnrollTOTPReply, error) {
+	e, err := s.tc.Enroll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.EnrollTOTPReply{Secret: e.Secret, Uri: e.URI}, nil
+}
+
+func (s *UserService) ConfirmTOTP(ctx context.Context, req *v1.ConfirmTOTPReq) (*v1.ConfirmTOTPReply, error) {
+	codes, err := s.tc.Confirm(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ConfirmTOTPReply{RecoveryCodes: codes}, nil
+}
+
+func ConvertToLoginReply(t *biz.Token) *v1.LoginReply {
+	if t.MfaToken != "" {
+		return &v1.LoginReply{
+			MfaRequired: true,
+			MfaToken:    t.MfaToken,
+			ExpiresIn:   int64(t.ExpiresIn.Seconds()),
+		}
+	}
+	return &v1.LoginReply{
+		AccessToken:      t.AccessToken,
+		RefreshToken:     t.RefreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(t.ExpiresIn.Seconds()),
+		RefreshExpiresIn: int64(t.RefreshExpiresIn.Seconds()),
+	}
+}
+
+func (s *UserService) RefreshToken(ctx context.Context, req *v1.RefreshTokenReq) (*v1.LoginReply, error) {
+	token, err := s.ac.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertToLoginReply(token), nil
+}
+
+func (s *UserService) Logout(ctx context.Context, req *v1.LogoutReq) (*emptypb.Empty, error) {
+	err := s.ac.Logout(ctx, req.RefreshToken)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) LogoutAll(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	err := s.ac.LogoutAll(ctx)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) GrantRole(ctx context.Context, req *v1.UserRoleReq) (*emptypb.Empty, error) {
+	err := s.rc.GrantRole(ctx, req.UserId, req.Role)
+	return &emptypb.Empty{}, err
+}
+
+func (s *UserService) RevokeRole(ctx context.Context, req *v1.UserRoleReq) (*emptypb.Empty, error) {
+	err := s.rc.RevokeRole(ctx, req.UserId, req.Role)
+	return &emptypb.Empty{}, err
+}
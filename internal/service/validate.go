@@ -0,0 +1,194 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	// 运行镜像中没有时区数据，校验时区时使用内嵌的数据
+	_ "time/tzdata"
+	"unicode"
+	"unicode/utf8"
+
+	v1 "github.com/lovechung/api-base/api/user"
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"user-service/internal/pkg/totp"
+	"user-service/internal/pkg/validate"
+)
+
+const (
+	usernameMinLen = 3
+	usernameMaxLen = 32
+	passwordMaxLen = 128
+	maxPageSize    = 100
+	maxBatchIds    = 200
+	maxReasonLen   = 255
+	maxRoleLen     = 64
+	maxMfaCodeLen  = 16
+	maxEmailLen    = 254
+	// 游标中带有用户名查询条件，留出足够余量
+	maxPageTokenLen = 512
+	maxNicknameLen  = 32
+	maxAvatarLen    = 512
+	maxBioLen       = 500
+	// attributes编码后的最大字节数
+	maxAttributesSize = 4096
+)
+
+// E.164格式的手机号
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// ValidateRequest 各接口请求参数的校验规则，未列出的请求不做校验
+func ValidateRequest(req interface{}, v *validate.Violations) {
+	switch r := req.(type) {
+	case *v1.ListUserReq:
+		if r.Page != nil {
+			v.Check(*r.Page >= 1, "page", "页码须大于0")
+		}
+		if r.PageSize != nil {
+			v.Check(*r.PageSize >= 1 && *r.PageSize <= maxPageSize, "page_size", "每页条数须在1~%d之间", maxPageSize)
+		}
+		if r.Username != nil {
+			v.Check(utf8.RuneCountInString(*r.Username) <= usernameMaxLen, "username", "用户名最长%d个字符", usernameMaxLen)
+		}
+		v.Check(len(r.PageToken) <= maxPageTokenLen, "page_token", "分页游标最长%d个字符", maxPageTokenLen)
+	case *v1.GetUserReq:
+		checkId(v, "id", r.Id)
+	case *v1.UserIdsReq:
+		v.Check(len(r.Ids) > 0 && len(r.Ids) <= maxBatchIds, "ids", "用户id数量须在1~%d之间", maxBatchIds)
+		for i, id := range r.Ids {
+			checkId(v, fmt.Sprintf("ids[%d]", i), id)
+		}
+	case *wrapperspb.Int64Value:
+		checkId(v, "value", r.Value)
+	case *wrapperspb.StringValue:
+		checkUsername(v, "value", r.Value)
+	case *v1.SaveUserReq:
+		checkUsername(v, "username", r.Username)
+		checkPassword(v, "password", r.Password)
+		if r.Email != nil {
+			checkEmail(v, "email", *r.Email)
+		}
+	case *v1.UpdateUserReq:
+		checkId(v, "id", r.Id)
+		if r.Username != nil {
+			checkUsername(v, "username", *r.Username)
+		}
+		if r.Password != nil {
+			checkPassword(v, "password", *r.Password)
+		}
+		if r.Email != nil {
+			checkEmail(v, "email", *r.Email)
+		}
+		checkProfile(v, r)
+		if r.Version != nil {
+			v.Check(*r.Version > 0, "version", "版本号须大于0")
+		}
+	case *v1.LoginReq:
+		// 历史用户名可能不符合注册时的格式要求，登录时只限制长度
+		n := utf8.RuneCountInString(r.Username)
+		v.Check(n > 0 && n <= usernameMaxLen, "username", "用户名不能为空且最长%d个字符", usernameMaxLen)
+		checkPassword(v, "password", r.Password)
+	case *v1.RequestPasswordResetReq:
+		v.Check(r.Account != "" && len(r.Account) <= maxEmailLen, "account", "账号不能为空且最长%d个字符", maxEmailLen)
+	case *v1.ResetPasswordReq:
+		v.Check(r.Token != "", "token", "token不能为空")
+		checkPassword(v, "new_password", r.NewPassword)
+	case *v1.VerifyEmailReq:
+		v.Check(r.Token != "", "token", "token不能为空")
+	case *v1.RefreshTokenReq:
+		v.Check(r.RefreshToken != "", "refresh_token", "refresh token不能为空")
+	case *v1.LogoutReq:
+		v.Check(r.RefreshToken != "", "refresh_token", "refresh token不能为空")
+	case *v1.VerifyMfaReq:
+		v.Check(r.MfaToken != "", "mfa_token", "mfa token不能为空")
+		v.Check(r.Code != "" && len(r.Code) <= maxMfaCodeLen, "code", "验证码不能为空且最长%d个字符", maxMfaCodeLen)
+	case *v1.ConfirmTOTPReq:
+		v.Check(len(r.Code) == totp.Digits, "code", "验证码须为%d位数字", totp.Digits)
+	case *v1.UserRoleReq:
+		checkId(v, "user_id", r.UserId)
+		v.Check(r.Role != "" && len(r.Role) <= maxRoleLen, "role", "角色名长度须在1~%d之间", maxRoleLen)
+	case *v1.ClearLockoutReq:
+		v.Check(r.UserId >= 0, "user_id", "id不能为负数")
+		v.Check(r.UserId != 0 || r.Ip != "", "user_id", "用户id与ip至少指定一个")
+		if r.Ip != "" {
+			v.Check(net.ParseIP(r.Ip) != nil, "ip", "ip格式不正确")
+		}
+	case *v1.ChangeUserStatusReq:
+		checkId(v, "id", r.Id)
+		v.Check(utf8.RuneCountInString(r.Reason) <= maxReasonLen, "reason", "原因最长%d个字符", maxReasonLen)
+	}
+}
+
+func checkId(v *validate.Violations, field string, id int64) {
+	v.Check(id > 0, field, "id须大于0")
+}
+
+// checkUsername 用户名由字母、数字及_.-组成，首尾不能有空白
+func checkUsername(v *validate.Violations, field, username string) {
+	n := utf8.RuneCountInString(username)
+	if n < usernameMinLen || n > usernameMaxLen {
+		v.Add(field, "用户名长度须在%d~%d之间", usernameMinLen, usernameMaxLen)
+		return
+	}
+	if strings.TrimSpace(username) != username {
+		v.Add(field, "用户名首尾不能有空白")
+		return
+	}
+	for _, c := range username {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && !strings.ContainsRune("_.-", c) {
+			v.Add(field, "用户名只能包含字母、数字及_.-")
+			return
+		}
+	}
+}
+
+// checkPassword 只限制长度上限避免哈希计算过慢，强度要求由biz.PasswordPolicy校验
+func checkPassword(v *validate.Violations, field, password string) {
+	n := utf8.RuneCountInString(password)
+	v.Check(n > 0 && n <= passwordMaxLen, field, "密码不能为空且最长%d个字符", passwordMaxLen)
+}
+
+// checkEmail 只接受不带显示名的纯地址
+func checkEmail(v *validate.Violations, field, email string) {
+	if len(email) > maxEmailLen {
+		v.Add(field, "邮箱最长%d个字符", maxEmailLen)
+		return
+	}
+	addr, err := mail.ParseAddress(email)
+	v.Check(err == nil && addr.Address == strings.TrimSpace(email), field, "邮箱格式不正确")
+}
+
+// checkProfile 资料字段为空字符串时表示清空，不做格式校验
+func checkProfile(v *validate.Violations, r *v1.UpdateUserReq) {
+	if r.Nickname != nil {
+		v.Check(utf8.RuneCountInString(*r.Nickname) <= maxNicknameLen, "nickname", "昵称最长%d个字符", maxNicknameLen)
+	}
+	if r.AvatarUrl != nil && *r.AvatarUrl != "" {
+		u, err := url.Parse(*r.AvatarUrl)
+		v.Check(len(*r.AvatarUrl) <= maxAvatarLen && err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"avatar_url", "头像地址须为http(s)链接且最长%d个字符", maxAvatarLen)
+	}
+	if r.Phone != nil && *r.Phone != "" {
+		v.Check(phonePattern.MatchString(*r.Phone), "phone", "手机号须为+国家码开头的E.164格式")
+	}
+	if r.Locale != nil && *r.Locale != "" {
+		_, err := language.Parse(*r.Locale)
+		v.Check(err == nil, "locale", "语言须为BCP 47格式，如zh-CN")
+	}
+	if r.Timezone != nil && *r.Timezone != "" {
+		_, err := time.LoadLocation(*r.Timezone)
+		v.Check(err == nil && *r.Timezone != "Local", "timezone", "时区须为IANA时区名，如Asia/Shanghai")
+	}
+	if r.Bio != nil {
+		v.Check(utf8.RuneCountInString(*r.Bio) <= maxBioLen, "bio", "简介最长%d个字符", maxBioLen)
+	}
+	if r.Attributes != nil {
+		v.Check(proto.Size(r.Attributes) <= maxAttributesSize, "attributes", "扩展属性最大%d字节", maxAttributesSize)
+	}
+}
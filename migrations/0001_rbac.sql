@@ -0,0 +1,42 @@
+-- 角色、权限及授权关系，与 internal/data/ent/schema/role.go、permission.go 保持一致
+
+CREATE TABLE IF NOT EXISTS `role`
+(
+    `id`          bigint       NOT NULL AUTO_INCREMENT,
+    `name`        varchar(255) NOT NULL,
+    `description` varchar(255) NULL,
+    `created_at`  timestamp    NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `name` (`name`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `permission`
+(
+    `id`          bigint       NOT NULL AUTO_INCREMENT,
+    `code`        varchar(255) NOT NULL,
+    `description` varchar(255) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `code` (`code`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `role_permission`
+(
+    `role_id`       bigint NOT NULL,
+    `permission_id` bigint NOT NULL,
+    PRIMARY KEY (`role_id`, `permission_id`),
+    CONSTRAINT `role_permission_role_id` FOREIGN KEY (`role_id`) REFERENCES `role` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `role_permission_permission_id` FOREIGN KEY (`permission_id`) REFERENCES `permission` (`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `user_role`
+(
+    `user_id` bigint NOT NULL,
+    `role_id` bigint NOT NULL,
+    PRIMARY KEY (`user_id`, `role_id`),
+    CONSTRAINT `user_role_user_id` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `user_role_role_id` FOREIGN KEY (`role_id`) REFERENCES `role` (`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,29 @@
+-- 初始化权限及内置角色，可重复执行
+-- 权限码与 internal/biz/role.go 中的常量保持一致
+
+INSERT IGNORE INTO `permission` (`code`, `description`)
+VALUES ('user:update', '修改任意用户信息'),
+       ('user:delete', '删除及恢复用户'),
+       ('user:freeze', '冻结、解冻及禁用用户'),
+       ('user:unlock', '解除登录锁定'),
+       ('role:grant', '授予及收回角色');
+
+INSERT IGNORE INTO `role` (`name`, `description`, `created_at`)
+VALUES ('admin', '管理员，拥有全部权限', NOW());
+
+INSERT IGNORE INTO `role_permission` (`role_id`, `permission_id`)
+SELECT r.`id`, p.`id`
+FROM `role` r
+         CROSS JOIN `permission` p
+WHERE r.`name` = 'admin';
+
+-- 初始管理员：按用户id授予，不按用户名匹配，避免他人抢注同名账号获得管理员权限。
+-- 执行前将@admin_user_id改为已确认的用户id，保持NULL时不授予任何用户
+SET @admin_user_id = NULL;
+
+INSERT INTO `user_role` (`user_id`, `role_id`)
+SELECT @admin_user_id, r.`id`
+FROM `role` r
+WHERE r.`name` = 'admin'
+  AND @admin_user_id IS NOT NULL
+ON DUPLICATE KEY UPDATE `role_id` = `role_id`;
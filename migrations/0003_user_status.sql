@@ -0,0 +1,8 @@
+-- 账号状态，与 internal/biz/status.go 保持一致；原有user表为(id, username, password, created_at, updated_at)
+-- 已有账号均视为已激活
+
+ALTER TABLE `user`
+    ADD COLUMN `status`            enum ('pending','active','frozen','disabled') NOT NULL DEFAULT 'active' AFTER `updated_at`,
+    ADD COLUMN `status_reason`     varchar(255) NULL AFTER `status`,
+    ADD COLUMN `status_changed_by` bigint       NULL AFTER `status_reason`,
+    ADD COLUMN `status_changed_at` timestamp    NULL AFTER `status_changed_by`;
@@ -0,0 +1,4 @@
+-- 软删除时间，为空表示未删除，见 internal/data/ent/schema/softdelete.go
+
+ALTER TABLE `user`
+    ADD COLUMN `deleted_at` timestamp NULL AFTER `status_changed_at`;
@@ -0,0 +1,21 @@
+-- 规范化用户名的唯一键，见 internal/biz/username.go NormalizeUsername
+-- 先回填已有用户再建唯一索引，避免历史用户名被新注册的账号占用
+
+ALTER TABLE `user`
+    ADD COLUMN `username_key` varchar(255) NULL AFTER `username`;
+
+-- 纯ASCII的用户名规范化后即为去除首尾空格后的小写形式，直接在SQL中回填；
+-- 含其他字符的用户名由服务启动后按NormalizeUsername回填
+UPDATE `user`
+SET `username_key` = LOWER(TRIM(`username`))
+WHERE `username_key` IS NULL
+  AND `username` IS NOT NULL
+  AND TRIM(`username`) <> ''
+  AND CHAR_LENGTH(`username`) = LENGTH(`username`);
+
+-- 建索引前先检查冲突，以下查询有结果时需先人工处理重名的账号：
+-- SELECT `username_key`, GROUP_CONCAT(`id`) FROM `user`
+-- WHERE `username_key` IS NOT NULL GROUP BY `username_key` HAVING COUNT(*) > 1;
+
+ALTER TABLE `user`
+    ADD UNIQUE KEY `username_key` (`username_key`);
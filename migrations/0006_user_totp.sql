@@ -0,0 +1,26 @@
+-- 两步验证密钥及恢复码，与 internal/data/ent/schema/user_totp.go、recovery_code.go 保持一致
+
+CREATE TABLE IF NOT EXISTS `user_totp`
+(
+    `id`             bigint       NOT NULL AUTO_INCREMENT,
+    `user_id`        bigint       NOT NULL,
+    `secret`         varchar(255) NOT NULL,
+    `confirmed_at`   timestamp    NULL,
+    `last_used_step` bigint       NULL,
+    `created_at`     timestamp    NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `user_id` (`user_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `user_recovery_code`
+(
+    `id`         bigint       NOT NULL AUTO_INCREMENT,
+    `user_id`    bigint       NOT NULL,
+    `code_hash`  varchar(255) NOT NULL,
+    `used_at`    timestamp    NULL,
+    `created_at` timestamp    NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `recoverycode_user_id_code_hash` (`user_id`, `code_hash`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,6 @@
+-- 邮箱及验证时间，邮箱存储为规范化后的形式，见 internal/biz/user.go NormalizeEmail
+
+ALTER TABLE `user`
+    ADD COLUMN `email`             varchar(255) NULL AFTER `password`,
+    ADD COLUMN `email_verified_at` timestamp    NULL AFTER `email`,
+    ADD UNIQUE KEY `email` (`email`);
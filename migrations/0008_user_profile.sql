@@ -0,0 +1,10 @@
+-- 用户资料字段，均可为空，通过UpdateUser的字段掩码修改
+
+ALTER TABLE `user`
+    ADD COLUMN `nickname`   varchar(255) NULL AFTER `email_verified_at`,
+    ADD COLUMN `avatar_url` varchar(255) NULL AFTER `nickname`,
+    ADD COLUMN `phone`      varchar(255) NULL AFTER `avatar_url`,
+    ADD COLUMN `locale`     varchar(255) NULL AFTER `phone`,
+    ADD COLUMN `timezone`   varchar(255) NULL AFTER `locale`,
+    ADD COLUMN `bio`        longtext     NULL AFTER `timezone`,
+    ADD COLUMN `attributes` json         NULL AFTER `bio`;
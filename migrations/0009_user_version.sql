@@ -0,0 +1,4 @@
+-- 乐观锁版本号，已有用户从1开始
+
+ALTER TABLE `user`
+    ADD COLUMN `version` bigint NOT NULL DEFAULT 1 AFTER `deleted_at`;